@@ -1,6 +1,9 @@
 package e2e_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -341,4 +344,96 @@ var _ = Describe("Observability Features", Ordered, func() {
 			Expect(metricsText).To(ContainSubstring(`path=`))
 		})
 	})
+
+	Describe("Metrics Endpoint Authentication", func() {
+		var signingKey ed25519.PrivateKey
+
+		BeforeAll(func() {
+			rawKey := os.Getenv("METRICS_JWT_SIGNING_KEY")
+			if rawKey == "" {
+				Skip("METRICS_JWT_SIGNING_KEY not set - metrics auth is not configured on this environment")
+			}
+			decoded, err := base64.StdEncoding.DecodeString(rawKey)
+			Expect(err).NotTo(HaveOccurred())
+			signingKey = ed25519.PrivateKey(decoded)
+		})
+
+		signJWT := func(claims map[string]interface{}, kid string) string {
+			header := map[string]interface{}{"alg": "EdDSA", "typ": "JWT", "kid": kid}
+			headerJSON, _ := json.Marshal(header)
+			claimsJSON, _ := json.Marshal(claims)
+			signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+			sig := ed25519.Sign(signingKey, []byte(signingInput))
+			return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+		}
+
+		It("should reject a request with no Authorization header", func() {
+			resp, err := client.Get(metricsURL)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should reject an expired token", func() {
+			token := signJWT(map[string]interface{}{
+				"iss": "apify",
+				"aud": "apify-metrics",
+				"exp": time.Now().Add(-time.Hour).Unix(),
+				"nbf": time.Now().Add(-2 * time.Hour).Unix(),
+			}, "e2e-metrics-key")
+
+			req, _ := http.NewRequest("GET", metricsURL, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should reject a token signed with the wrong key", func() {
+			_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			header := map[string]interface{}{"alg": "EdDSA", "typ": "JWT", "kid": "e2e-metrics-key"}
+			headerJSON, _ := json.Marshal(header)
+			claims := map[string]interface{}{
+				"iss": "apify",
+				"aud": "apify-metrics",
+				"exp": time.Now().Add(time.Hour).Unix(),
+				"nbf": time.Now().Add(-time.Minute).Unix(),
+			}
+			claimsJSON, _ := json.Marshal(claims)
+			signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+			sig := ed25519.Sign(otherKey, []byte(signingInput))
+			token := signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+
+			req, _ := http.NewRequest("GET", metricsURL, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should accept a valid token and still expose the usual metrics", func() {
+			token := signJWT(map[string]interface{}{
+				"iss":   "apify",
+				"aud":   "apify-metrics",
+				"exp":   time.Now().Add(time.Hour).Unix(),
+				"nbf":   time.Now().Add(-time.Minute).Unix(),
+				"roles": []string{"metrics-scraper"},
+			}, "e2e-metrics-key")
+
+			req, _ := http.NewRequest("GET", metricsURL, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(ContainSubstring("apify_http_requests_total"))
+		})
+	})
 })
@@ -0,0 +1,167 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Alerting Rules Subsystem", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{})
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	loadRuleGroups := func(yaml string) {
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/alerting/rules", "application/yaml", strings.NewReader(yaml))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	}
+
+	It("should list loaded rule groups with their current evaluation state", func() {
+		loadRuleGroups(`
+groups:
+  - name: http-errors
+    interval: 1s
+    rules:
+      - alert: HighErrorRate
+        expr: sum(rate(apify_http_requests_total{status="500"}[1m])) > 0
+        for: 0s
+        labels:
+          severity: critical
+        annotations:
+          summary: "error rate is non-zero"
+`)
+
+		resp, err := client.Get(env.CPBaseURL + "/api/v1/rules")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var body map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+		data := body["data"].(map[string]interface{})
+		groups := data["groups"].([]interface{})
+		Expect(groups).To(HaveLen(1))
+		group := groups[0].(map[string]interface{})
+		Expect(group["name"]).To(Equal("http-errors"))
+		rules := group["rules"].([]interface{})
+		Expect(rules).To(HaveLen(1))
+		rule := rules[0].(map[string]interface{})
+		Expect(rule["name"]).To(Equal("HighErrorRate"))
+		Expect(rule["health"]).NotTo(BeEmpty())
+		Expect(rule["state"]).To(BeElementOf("inactive", "pending", "firing"))
+	})
+
+	It("should transition a canary rule to firing once matching 500s are generated", func() {
+		loadRuleGroups(`
+groups:
+  - name: canary
+    interval: 1s
+    rules:
+      - alert: CanaryFiring
+        expr: sum(rate(apify_http_requests_total{status="500"}[1m])) > 0
+        for: 0s
+        labels:
+          severity: page
+        annotations:
+          summary: "canary alert"
+`)
+
+		req, err := http.NewRequest("GET", env.BaseURL+"/items", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		req.Header.Set("X-Apify-Force-Panic", "true")
+		for i := 0; i < 5; i++ {
+			resp, err := client.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}
+
+		Eventually(func() string {
+			resp, err := client.Get(env.CPBaseURL + "/api/v1/alerts")
+			if err != nil {
+				return ""
+			}
+			defer resp.Body.Close()
+			var body map[string]interface{}
+			if json.NewDecoder(resp.Body).Decode(&body) != nil {
+				return ""
+			}
+			data, ok := body["data"].(map[string]interface{})
+			if !ok {
+				return ""
+			}
+			alerts, ok := data["alerts"].([]interface{})
+			if !ok || len(alerts) == 0 {
+				return ""
+			}
+			for _, a := range alerts {
+				alert := a.(map[string]interface{})
+				labels := alert["labels"].(map[string]interface{})
+				if labels["alertname"] == "CanaryFiring" {
+					return alert["state"].(string)
+				}
+			}
+			return ""
+		}, "10s", "250ms").Should(Equal("firing"))
+	})
+
+	It("should reject an invalid expression with the offending rule name", func() {
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/alerting/rules", "application/yaml", strings.NewReader(`
+groups:
+  - name: broken
+    rules:
+      - alert: BadExpr
+        expr: "sum(("
+        for: 0s
+`))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+		var errBody map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&errBody)).To(Succeed())
+		Expect(errBody["error"]).NotTo(BeEmpty())
+		Expect(errBody["error"]).To(ContainSubstring("BadExpr"))
+	})
+
+	It("should persist alertmanager targets and accept them on reload", func() {
+		payload := map[string]interface{}{
+			"alertmanagers": []map[string]interface{}{
+				{"url": "http://127.0.0.1:9093"},
+			},
+		}
+		body, _ := json.Marshal(payload)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/alerting/config", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		getResp, err := client.Get(env.CPBaseURL + "/apify/admin/alerting/config")
+		Expect(err).NotTo(HaveOccurred())
+		defer getResp.Body.Close()
+		var cfg map[string]interface{}
+		Expect(json.NewDecoder(getResp.Body).Decode(&cfg)).To(Succeed())
+		ams := cfg["alertmanagers"].([]interface{})
+		Expect(ams).To(HaveLen(1))
+	})
+})
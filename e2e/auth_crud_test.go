@@ -225,5 +225,46 @@ var _ = Describe("Auth Configs CRUD Operations", func() {
 			Expect(decodeJSON(resp, &auth)).To(Succeed())
 			Expect(auth["id"]).To(Equal(authID))
 		})
+
+		It("should accept an oauth2 auth config with JWKS discovery and a consumer-claim mapping", func() {
+			baseURL := env.CPBaseURL + "/apify/admin/auth"
+
+			authConfig := map[string]interface{}{
+				"type":    "oauth2",
+				"name":    "oauth2-auth",
+				"enabled": true,
+				"config": map[string]interface{}{
+					"issuer_url":     "https://idp.example.com/realms/apify",
+					"audiences":      []string{"apify"},
+					"token_source":   map[string]interface{}{"type": "header", "name": "Authorization"},
+					"consumer_claim": "sub",
+					"groups_claim":   "groups",
+					"consumer_map":   map[string]interface{}{"admins": "default"},
+				},
+			}
+			body, _ := json.Marshal(authConfig)
+			resp, err := client.Post(baseURL, "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+			var createResult map[string]interface{}
+			Expect(decodeJSON(resp, &createResult)).To(Succeed())
+			authID := createResult["id"].(string)
+
+			resp, err = client.Get(baseURL + "/" + authID)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			var auth map[string]interface{}
+			Expect(decodeJSON(resp, &auth)).To(Succeed())
+			configStr, ok := auth["config"].(string)
+			Expect(ok).To(BeTrue())
+			var configObj map[string]interface{}
+			Expect(json.Unmarshal([]byte(configStr), &configObj)).To(Succeed())
+			Expect(configObj["issuer_url"]).To(Equal("https://idp.example.com/realms/apify"))
+			Expect(configObj["consumer_claim"]).To(Equal("sub"))
+		})
 	})
 })
@@ -0,0 +1,205 @@
+package e2e_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// buildBundle packages the given files into a tar.gz bundle under tmpDir,
+// matching the apify.yaml + spec + seed-sql layout described in the bundles
+// install endpoint.
+func buildBundle(tmpDir string, files map[string]string) string {
+	bundlePath := filepath.Join(tmpDir, "bundle.tar.gz")
+	f, err := os.Create(bundlePath)
+	Expect(err).NotTo(HaveOccurred())
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	for name, content := range files {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}
+		Expect(tw.WriteHeader(hdr)).To(Succeed())
+		_, err := tw.Write([]byte(content))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	return bundlePath
+}
+
+var _ = Describe("Importable API Bundles", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		env, client, err = SetupControlPlaneEnv()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should install a bundle, run seed migrations, and register its API", func() {
+		manifest := `name: users-blog
+version: 1.0.0
+drivers: [sqlite]
+plugins: []
+`
+		spec := `openapi: 3.0.0
+info:
+  title: Users Blog
+  version: 1.0.0
+paths:
+  /posts:
+    get:
+      responses:
+        "200":
+          description: List posts
+components:
+  schemas:
+    Post:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        title:
+          type: string
+      x-table-schema:
+        tableName: posts
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: title
+            columnType: text
+`
+		seed := `INSERT INTO posts (title) VALUES ('Hello, Apify');`
+
+		bundlePath := buildBundle(env.TmpDir, map[string]string{
+			"apify.yaml":               manifest,
+			"specs/posts.yaml":         spec,
+			"migrations/0001_seed.sql": seed,
+		})
+
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		fw, err := mw.CreateFormFile("bundle", filepath.Base(bundlePath))
+		Expect(err).NotTo(HaveOccurred())
+		f, err := os.Open(bundlePath)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = io.Copy(fw, f)
+		Expect(err).NotTo(HaveOccurred())
+		f.Close()
+		Expect(mw.Close()).To(Succeed())
+
+		req, err := http.NewRequest("POST", env.CPBaseURL+"/apify/admin/bundles", &buf)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		var bundle map[string]interface{}
+		Expect(decodeJSON(resp, &bundle)).To(Succeed())
+		bundleID := bundle["id"].(string)
+
+		listResp, err := client.Get(env.CPBaseURL + "/apify/admin/bundles")
+		Expect(err).NotTo(HaveOccurred())
+		defer listResp.Body.Close()
+		Expect(listResp.StatusCode).To(Equal(http.StatusOK))
+
+		installPayload := map[string]interface{}{
+			"datasource": "default",
+			"listener":   "main",
+		}
+		body, _ := json.Marshal(installPayload)
+		installResp, err := client.Post(env.CPBaseURL+"/apify/admin/bundles/"+bundleID+"/install", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer installResp.Body.Close()
+		Expect(installResp.StatusCode).To(Equal(http.StatusOK))
+
+		apisResp, err := client.Get(env.CPBaseURL + "/apify/admin/apis")
+		Expect(err).NotTo(HaveOccurred())
+		defer apisResp.Body.Close()
+		var apis []map[string]interface{}
+		Expect(decodeJSON(apisResp, &apis)).To(Succeed())
+
+		found := false
+		for _, api := range apis {
+			if api["name"] == "users-blog" {
+				found = true
+			}
+		}
+		Expect(found).To(BeTrue(), "installed bundle should register its API")
+	})
+
+	It("should roll back uninstall and remove the registered API", func() {
+		manifest := `name: minimal-bundle
+version: 1.0.0
+drivers: [sqlite]
+plugins: []
+`
+		spec := `openapi: 3.0.0
+info:
+  title: Minimal
+  version: 1.0.0
+paths: {}
+`
+		bundlePath := buildBundle(env.TmpDir, map[string]string{"apify.yaml": manifest, "specs/minimal.yaml": spec})
+
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		fw, _ := mw.CreateFormFile("bundle", filepath.Base(bundlePath))
+		f, _ := os.Open(bundlePath)
+		io.Copy(fw, f)
+		f.Close()
+		mw.Close()
+
+		req, _ := http.NewRequest("POST", env.CPBaseURL+"/apify/admin/bundles", &buf)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		var bundle map[string]interface{}
+		Expect(decodeJSON(resp, &bundle)).To(Succeed())
+		bundleID := bundle["id"].(string)
+
+		installResp, err := client.Post(env.CPBaseURL+"/apify/admin/bundles/"+bundleID+"/install", "application/json",
+			bytes.NewBuffer([]byte(`{"datasource":"default","listener":"main"}`)))
+		Expect(err).NotTo(HaveOccurred())
+		installResp.Body.Close()
+
+		uninstallResp, err := client.Post(env.CPBaseURL+"/apify/admin/bundles/"+bundleID+"/uninstall", "application/json", bytes.NewReader(nil))
+		Expect(err).NotTo(HaveOccurred())
+		defer uninstallResp.Body.Close()
+		Expect(uninstallResp.StatusCode).To(Equal(http.StatusOK))
+
+		apisResp, err := client.Get(env.CPBaseURL + "/apify/admin/apis")
+		Expect(err).NotTo(HaveOccurred())
+		defer apisResp.Body.Close()
+		var apis []map[string]interface{}
+		Expect(decodeJSON(apisResp, &apis)).To(Succeed())
+		for _, api := range apis {
+			Expect(api["name"]).NotTo(Equal("minimal-bundle"))
+		}
+	})
+})
@@ -0,0 +1,326 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+)
+
+// startCachedTestEnv boots a Control Plane + Data Plane pair against the
+// Orders/Items and Users/Profiles fixtures the same way
+// startWorkloadIdentityEnv does, except the generated config carries a
+// `cache: {driver: redis, url: ...}` block from the moment the data plane
+// first starts, since nothing in this suite demonstrates a file-watch on
+// config.yaml after boot — the only supported live-reload path is the
+// CP-pushed admin API, picked up via DB polling. The suite is skipped when
+// REDIS_URL isn't set.
+func startCachedTestEnv(ttlSeconds int) *TestEnv {
+	env := &TestEnv{}
+
+	wd, _ := os.Getwd()
+	projectRoot := filepath.Dir(wd)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	dpPort := fmt.Sprintf("%d", l.Addr().(*net.TCPAddr).Port)
+	l.Close()
+	env.BaseURL = "http://127.0.0.1:" + dpPort
+
+	l, err = net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	cpPort := fmt.Sprintf("%d", l.Addr().(*net.TCPAddr).Port)
+	l.Close()
+	env.CPBaseURL = "http://127.0.0.1:" + cpPort
+
+	env.TmpDir, err = os.MkdirTemp("", "apify-e2e-cache")
+	Expect(err).NotTo(HaveOccurred())
+	env.ConfigFile = filepath.Join(env.TmpDir, "config.yaml")
+	env.DBFile = filepath.Join(env.TmpDir, "test.sqlite")
+
+	f, err := os.Create(env.DBFile)
+	Expect(err).NotTo(HaveOccurred())
+	f.Close()
+
+	env.APIKey = "e2e-test-key-001"
+	configContent := fmt.Sprintf(`
+control-plane:
+  listen:
+    ip: 127.0.0.1
+    port: %s
+  database:
+    driver: sqlite
+    database: //%s
+
+listeners:
+  - port: %s
+    ip: 127.0.0.1
+    protocol: HTTP
+    apis: []
+
+auth:
+  - name: e2e-api-keys
+    type: api-key
+    enabled: true
+    config:
+      source: header
+      key_name: X-Api-Key
+      consumers:
+        - name: default
+          keys:
+            - %s
+
+datasource:
+  default:
+    driver: sqlite
+    database: //%s
+    max_pool_size: 1
+
+cache:
+  driver: redis
+  url: %s
+  default_ttl_seconds: %d
+
+log_level: "info"
+`, cpPort, env.DBFile, dpPort, env.APIKey, env.DBFile, os.Getenv("REDIS_URL"), ttlSeconds)
+
+	Expect(os.WriteFile(env.ConfigFile, []byte(configContent), 0644)).To(Succeed())
+
+	env.CPCmd = exec.Command("cargo", "run", "--bin", "apify", "--", "--control-plane", "--config", env.ConfigFile)
+	env.CPCmd.Dir = projectRoot
+	env.CPCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+env.DBFile)
+	env.CPCmd.Stdout = GinkgoWriter
+	env.CPCmd.Stderr = GinkgoWriter
+	Expect(env.CPCmd.Start()).To(Succeed())
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	Eventually(func() error {
+		resp, err := client.Get(env.CPBaseURL + "/_meta/apis")
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("status code %d", resp.StatusCode)
+		}
+		return nil
+	}, 60*time.Second, 1*time.Second).Should(Succeed())
+
+	var apiNames []string
+	for name, path := range map[string]string{
+		"orders": "examples/relations/config/openapi/orders.yaml",
+		"users":  "examples/relations/config/openapi/users.yaml",
+	} {
+		specContent, err := os.ReadFile(filepath.Join(projectRoot, path))
+		Expect(err).NotTo(HaveOccurred())
+		var specObj map[string]interface{}
+		Expect(yaml.Unmarshal(specContent, &specObj)).To(Succeed())
+
+		payloadBytes, err := json.Marshal(map[string]interface{}{
+			"name":    name,
+			"version": "1.0.0",
+			"spec":    specObj,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := client.Post(env.CPBaseURL+"/_meta/apis", "application/json", bytes.NewBuffer(payloadBytes))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(201))
+		resp.Body.Close()
+		apiNames = append(apiNames, name)
+	}
+
+	dpPortInt, err := strconv.Atoi(dpPort)
+	Expect(err).NotTo(HaveOccurred())
+	listenerPayload, err := json.Marshal(map[string]interface{}{
+		"port":     dpPortInt,
+		"ip":       "127.0.0.1",
+		"protocol": "HTTP",
+		"apis":     apiNames,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	resp, err := client.Post(env.CPBaseURL+"/_meta/listeners", "application/json", bytes.NewBuffer(listenerPayload))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(201))
+	resp.Body.Close()
+
+	env.ServerCmd = exec.Command("cargo", "run", "--bin", "apify", "--", "--data-plane", "--config", env.ConfigFile)
+	env.ServerCmd.Dir = projectRoot
+	env.ServerCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+env.DBFile, "APIFY_CONFIG_POLL_INTERVAL=1")
+	env.ServerCmd.Stdout = GinkgoWriter
+	env.ServerCmd.Stderr = GinkgoWriter
+	Expect(env.ServerCmd.Start()).To(Succeed())
+
+	Eventually(func() error {
+		resp, err := client.Get(env.BaseURL + "/healthz")
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("status %d", resp.StatusCode)
+		}
+		return nil
+	}, "60s", "1s").Should(Succeed(), "Server failed to start")
+
+	return env
+}
+
+var _ = Describe("Redis-backed Read-through Cache", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		if os.Getenv("REDIS_URL") == "" {
+			Skip("REDIS_URL not set; skipping redis-backed cache tests")
+		}
+		env = startCachedTestEnv(60)
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	createOrder := func(customerName string) float64 {
+		body := map[string]interface{}{
+			"customerName": customerName,
+			"total":        15.00,
+			"status":       "pending",
+			"items": []map[string]interface{}{
+				{"productName": "Cached Widget", "quantity": 1, "price": 15.00},
+			},
+		}
+		payload, _ := json.Marshal(body)
+		req, err := http.NewRequest("POST", env.BaseURL+"/orders", bytes.NewBuffer(payload))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		var created map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&created)).To(Succeed())
+		return created["id"].(float64)
+	}
+
+	cacheStats := func() map[string]interface{} {
+		resp, err := client.Get(env.CPBaseURL + "/apify/admin/cache/stats")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var stats map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&stats)).To(Succeed())
+		return stats
+	}
+
+	It("should serve a repeated GET from cache after the first miss", func() {
+		orderID := createOrder("Cache Hit Customer")
+		path := fmt.Sprintf("/orders/%d", int(orderID))
+
+		before := cacheStats()
+		missesBefore := before["misses"].(float64)
+		hitsBefore := before["hits"].(float64)
+
+		resp, err := client.Get(env.BaseURL + path)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		resp, err = client.Get(env.BaseURL + path)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		after := cacheStats()
+		Expect(after["misses"].(float64)).To(BeNumerically(">=", missesBefore+1))
+		Expect(after["hits"].(float64)).To(BeNumerically(">=", hitsBefore+1))
+	})
+
+	It("should expire a cached entry once its TTL elapses", func() {
+		env.Stop()
+		env = startCachedTestEnv(1)
+		orderID := createOrder("TTL Customer")
+		path := fmt.Sprintf("/orders/%d", int(orderID))
+
+		resp, err := client.Get(env.BaseURL + path)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		before := cacheStats()
+
+		time.Sleep(2 * time.Second)
+
+		resp, err = client.Get(env.BaseURL + path)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		after := cacheStats()
+		Expect(after["misses"].(float64)).To(BeNumerically(">", before["misses"].(float64)))
+	})
+
+	It("should invalidate an order's items list cache when the order is deleted", func() {
+		orderID := createOrder("Invalidation Customer")
+		itemsPath := fmt.Sprintf("/orders/%d?include=items", int(orderID))
+
+		resp, err := client.Get(env.BaseURL + itemsPath)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		req, err := http.NewRequest("DELETE", env.BaseURL+fmt.Sprintf("/orders/%d", int(orderID)), nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+
+		resp, err = client.Get(env.BaseURL + itemsPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	It("should purge the entire cache on a manual POST /apify/admin/cache/invalidate", func() {
+		orderID := createOrder("Manual Invalidate Customer")
+		path := fmt.Sprintf("/orders/%d", int(orderID))
+
+		resp, err := client.Get(env.BaseURL + path)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		before := cacheStats()
+
+		req, err := http.NewRequest("POST", env.CPBaseURL+"/apify/admin/cache/invalidate", bytes.NewBufferString(`{}`))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Type", "application/json")
+		resp, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		resp, err = client.Get(env.BaseURL + path)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		after := cacheStats()
+		Expect(after["misses"].(float64)).To(BeNumerically(">", before["misses"].(float64)))
+	})
+})
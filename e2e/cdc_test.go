@@ -0,0 +1,187 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+// startCDCTestEnv wires a webhook subscription, the simplest sink to assert
+// against in-process, up front so the relay worker has a consumer to deliver
+// to from the moment the data plane starts.
+func startCDCTestEnv(webhookURL string) *TestEnv {
+	env := StartTestEnv(map[string]string{
+		"orders": "examples/relations/config/openapi/orders.yaml",
+		"users":  "examples/relations/config/openapi/users.yaml",
+	})
+
+	sub := map[string]interface{}{
+		"name":   "e2e-webhook",
+		"sink":   map[string]interface{}{"type": "webhook", "url": webhookURL},
+		"filter": map[string]interface{}{"resources": []string{"orders", "order_items", "users"}},
+	}
+	payload, _ := json.Marshal(sub)
+	resp, err := http.Post(env.CPBaseURL+"/apify/admin/subscriptions", "application/json", bytes.NewBuffer(payload))
+	Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+	Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+	time.Sleep(2 * time.Second)
+	return env
+}
+
+var _ = Describe("Change-Data-Capture Event Stream", func() {
+	var (
+		env     *TestEnv
+		client  *http.Client
+		webhook *ghttp.Server
+		events  []map[string]interface{}
+	)
+
+	recordEvent := func(w http.ResponseWriter, r *http.Request) {
+		var evt map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&evt)
+		events = append(events, evt)
+		w.WriteHeader(http.StatusOK)
+	}
+
+	BeforeEach(func() {
+		webhook = ghttp.NewServer()
+		webhook.RouteToHandler("POST", "/cdc", recordEvent)
+		events = nil
+
+		env = startCDCTestEnv(webhook.URL() + "/cdc")
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+		if webhook != nil {
+			webhook.Close()
+		}
+	})
+
+	It("should relay a CloudEvent with {resource, op, id, before, after} on create", func() {
+		body := map[string]interface{}{
+			"customerName": "CDC Customer",
+			"total":        20.00,
+			"status":       "pending",
+			"items": []map[string]interface{}{
+				{"productName": "Tracked Widget", "quantity": 1, "price": 20.00},
+			},
+		}
+		payload, _ := json.Marshal(body)
+		resp, err := client.Post(env.BaseURL+"/orders", "application/json", bytes.NewBuffer(payload))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		Eventually(func() []map[string]interface{} { return events }, "10s", "200ms").ShouldNot(BeEmpty())
+
+		var created map[string]interface{}
+		found := false
+		for _, e := range events {
+			if e["resource"] == "orders" && e["op"] == "create" {
+				found = true
+				Expect(e["after"]).NotTo(BeNil())
+				Expect(e["before"]).To(BeNil())
+				created = e["after"].(map[string]interface{})
+			}
+		}
+		Expect(found).To(BeTrue())
+		Expect(created["customerName"]).To(Equal("CDC Customer"))
+	})
+
+	It("should emit one order.deleted plus N order_item.deleted events, in order, on cascade delete", func() {
+		body := map[string]interface{}{
+			"customerName": "Cascade Customer",
+			"total":        30.00,
+			"status":       "pending",
+			"items": []map[string]interface{}{
+				{"productName": "A", "quantity": 1, "price": 10.00},
+				{"productName": "B", "quantity": 1, "price": 20.00},
+			},
+		}
+		payload, _ := json.Marshal(body)
+		resp, err := client.Post(env.BaseURL+"/orders", "application/json", bytes.NewBuffer(payload))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		var created map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&created)).To(Succeed())
+		orderID := int(created["id"].(float64))
+
+		Eventually(func() []map[string]interface{} { return events }, "10s", "200ms").ShouldNot(BeEmpty())
+		events = nil
+
+		req, err := http.NewRequest("DELETE", env.BaseURL+fmt.Sprintf("/orders/%d", orderID), nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+
+		Eventually(func() int { return len(events) }, "10s", "200ms").Should(BeNumerically(">=", 3))
+
+		Expect(events[0]["resource"]).To(Equal("orders"))
+		Expect(events[0]["op"]).To(Equal("delete"))
+		for _, e := range events[1:] {
+			Expect(e["resource"]).To(Equal("order_items"))
+			Expect(e["op"]).To(Equal("delete"))
+		}
+	})
+
+	It("should support subscription CRUD parallel to the datasources admin API", func() {
+		resp, err := client.Get(env.CPBaseURL + "/apify/admin/subscriptions")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var subs []map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&subs)).To(Succeed())
+		Expect(subs).NotTo(BeEmpty())
+
+		id := subs[0]["id"]
+		req, err := http.NewRequest("DELETE", env.CPBaseURL+fmt.Sprintf("/apify/admin/subscriptions/%v", id), nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+	})
+
+	It("should still accept the mutation and queue the event for at-least-once delivery when the sink is briefly down", func() {
+		webhook.CloseClientConnections()
+		webhook.Close()
+
+		body := map[string]interface{}{
+			"customerName": "Retry Customer",
+			"total":        5.00,
+			"status":       "pending",
+			"items":        []map[string]interface{}{{"productName": "Z", "quantity": 1, "price": 5.00}},
+		}
+		payload, _ := json.Marshal(body)
+		resp, err := client.Post(env.BaseURL+"/orders", "application/json", bytes.NewBuffer(payload))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		// The relay worker retries with backoff against the now-unreachable
+		// sink; the mutation itself must not be rolled back or blocked on
+		// delivery succeeding.
+		resp2, err := client.Get(env.BaseURL + "/orders")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp2.Body.Close()
+		Expect(resp2.StatusCode).To(Equal(http.StatusOK))
+	})
+})
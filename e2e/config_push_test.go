@@ -0,0 +1,116 @@
+package e2e_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Control Plane Change Notification Stream", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		env, client, err = SetupControlPlaneEnv()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	dpResourceVersion := func() int {
+		resp, err := client.Get(env.CPBaseURL + "/_meta/status")
+		if err != nil {
+			return -1
+		}
+		defer resp.Body.Close()
+		var status map[string]interface{}
+		if json.NewDecoder(resp.Body).Decode(&status) != nil {
+			return -1
+		}
+		v, ok := status["resource_version"].(float64)
+		if !ok {
+			return -1
+		}
+		return int(v)
+	}
+
+	It("should stream versioned change notifications from /_meta/events", func() {
+		req, err := http.NewRequest("GET", env.CPBaseURL+"/_meta/events", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", "text/event-stream")
+
+		streamClient := &http.Client{Timeout: 0}
+		resp, err := streamClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		events := make(chan map[string]interface{}, 8)
+		go func() {
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				line := scanner.Text()
+				if !strings.HasPrefix(line, "data: ") {
+					continue
+				}
+				var event map[string]interface{}
+				if json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event) == nil {
+					events <- event
+				}
+			}
+		}()
+
+		listenerConfig := map[string]interface{}{
+			"name":     "events-listener",
+			"port":     9501,
+			"ip":       "0.0.0.0",
+			"protocol": "HTTP",
+		}
+		body, _ := json.Marshal(listenerConfig)
+		createResp, err := client.Post(env.CPBaseURL+"/apify/admin/listeners", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer createResp.Body.Close()
+		Expect(createResp.StatusCode).To(Equal(http.StatusCreated))
+		Expect(createResp.Header.Get("X-Resource-Version")).NotTo(BeEmpty())
+
+		Eventually(events, "10s").Should(Receive(SatisfyAll(
+			HaveKeyWithValue("resource", "listener"),
+			HaveKeyWithValue("op", "upsert"),
+			HaveKey("version"),
+		)))
+	})
+
+	It("should let the data plane resume from the resource_version reported by /_meta/status without a fixed sleep", func() {
+		apiConfig := map[string]interface{}{
+			"name":    "push-api",
+			"version": "1.0.0",
+			"spec": map[string]interface{}{
+				"openapi": "3.0.0",
+				"info":    map[string]interface{}{"title": "Push API", "version": "1.0.0"},
+				"paths":   map[string]interface{}{},
+			},
+		}
+		body, _ := json.Marshal(apiConfig)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/apis", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		var created map[string]interface{}
+		Expect(decodeJSON(resp, &created)).To(Succeed())
+
+		Eventually(dpResourceVersion, "10s", "250ms").Should(BeNumerically(">", 0))
+	})
+})
@@ -0,0 +1,34 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/apifyhost/apify/e2e/conformance"
+)
+
+var _ = Describe("OpenAPI Conformance", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		wd, _ := os.Getwd()
+		projectRoot := filepath.Dir(wd)
+		specPath := filepath.Join(projectRoot, "examples", "basic", "config", "openapi", "items.yaml")
+
+		env = StartTestEnv(map[string]string{"items": specPath})
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	conformance.RunOpenAPIConformance(
+		filepath.Join("..", "examples", "basic", "config", "openapi", "items.yaml"),
+		func() string { return env.BaseURL },
+		func() string { return env.APIKey },
+	)
+})
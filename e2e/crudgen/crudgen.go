@@ -0,0 +1,243 @@
+// Package crudgen generates the standard create/list/get/update/delete
+// lifecycle plus the usual negative-path coverage (auth, malformed JSON,
+// wrong content type, oversized body) for a single resource, so adding a
+// new table-backed API doesn't require hand-writing a full CRUD spec.
+package crudgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+// AuthMode selects how RunCRUDSuite authenticates its happy-path requests.
+type AuthMode int
+
+const (
+	// AuthAPIKey sends the configured API key on the X-Api-Key header.
+	AuthAPIKey AuthMode = iota
+	// AuthNone sends no authentication at all.
+	AuthNone
+)
+
+// CRUDSpec describes a single resource's expected CRUD contract.
+type CRUDSpec struct {
+	// Resource is the URL path segment, e.g. "widgets" for GET /widgets.
+	Resource string
+	// BaseURL is resolved lazily so the suite can be registered before the
+	// target environment has started.
+	BaseURL func() string
+	// APIKey is resolved lazily alongside BaseURL.
+	APIKey func() string
+	// CreatePayload and UpdatePayload are marshaled as the request bodies
+	// for creation and update respectively.
+	CreatePayload map[string]interface{}
+	UpdatePayload map[string]interface{}
+	// IDField names the response field RunCRUDSuite threads from create
+	// into subsequent get/update/delete calls. Defaults to "id".
+	IDField string
+	// ExpectedFields maps a response field name to a Gomega matcher that
+	// must pass against the created/updated resource.
+	ExpectedFields map[string]gomega.OmegaMatcher
+	// Auth selects the authentication mode used for happy-path requests.
+	Auth AuthMode
+	// MaxBodyBytes configures the 413 oversized-body negative test; it
+	// defaults to 1MiB if unset.
+	MaxBodyBytes int
+}
+
+func (s CRUDSpec) idField() string {
+	if s.IDField != "" {
+		return s.IDField
+	}
+	return "id"
+}
+
+func (s CRUDSpec) maxBodyBytes() int {
+	if s.MaxBodyBytes > 0 {
+		return s.MaxBodyBytes
+	}
+	return 1 << 20
+}
+
+func (s CRUDSpec) authedRequest(method, url string, body []byte) *http.Request {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	gomega.ExpectWithOffset(1, err).NotTo(gomega.HaveOccurred())
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.Auth == AuthAPIKey {
+		req.Header.Set("X-Api-Key", s.APIKey())
+	}
+	return req
+}
+
+// RunCRUDSuite registers an Ordered Ginkgo container under the currently
+// executing Describe/Context exercising the full lifecycle for spec.Resource.
+func RunCRUDSuite(spec CRUDSpec) {
+	ginkgo.Describe(fmt.Sprintf("CRUD lifecycle for /%s", spec.Resource), ginkgo.Ordered, func() {
+		client := &http.Client{Timeout: 10 * time.Second}
+		var createdID string
+
+		ginkgo.It("should start with an empty list", func() {
+			req := spec.authedRequest("GET", spec.BaseURL()+"/"+spec.Resource, nil)
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+			var list []map[string]interface{}
+			gomega.Expect(json.NewDecoder(resp.Body).Decode(&list)).To(gomega.Succeed())
+			gomega.Expect(list).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("should create a new resource", func() {
+			body, _ := json.Marshal(spec.CreatePayload)
+			req := spec.authedRequest("POST", spec.BaseURL()+"/"+spec.Resource, body)
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusCreated))
+
+			var created map[string]interface{}
+			gomega.Expect(json.NewDecoder(resp.Body).Decode(&created)).To(gomega.Succeed())
+			gomega.Expect(created[spec.idField()]).NotTo(gomega.BeNil())
+			createdID = fmt.Sprintf("%v", created[spec.idField()])
+
+			for field, matcher := range spec.ExpectedFields {
+				gomega.Expect(created[field]).To(matcher)
+			}
+		})
+
+		ginkgo.It("should list the created resource", func() {
+			req := spec.authedRequest("GET", spec.BaseURL()+"/"+spec.Resource, nil)
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+			var list []map[string]interface{}
+			gomega.Expect(json.NewDecoder(resp.Body).Decode(&list)).To(gomega.Succeed())
+			gomega.Expect(list).NotTo(gomega.BeEmpty())
+		})
+
+		ginkgo.It("should get the created resource by id", func() {
+			req := spec.authedRequest("GET", spec.BaseURL()+"/"+spec.Resource+"/"+createdID, nil)
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+		})
+
+		ginkgo.It("should update the resource", func() {
+			body, _ := json.Marshal(spec.UpdatePayload)
+			req := spec.authedRequest("PUT", spec.BaseURL()+"/"+spec.Resource+"/"+createdID, body)
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+		})
+
+		ginkgo.It("should reflect the update on a subsequent get", func() {
+			req := spec.authedRequest("GET", spec.BaseURL()+"/"+spec.Resource+"/"+createdID, nil)
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+			var updated map[string]interface{}
+			gomega.Expect(json.NewDecoder(resp.Body).Decode(&updated)).To(gomega.Succeed())
+			for field, value := range spec.UpdatePayload {
+				gomega.Expect(updated[field]).To(gomega.Equal(value))
+			}
+		})
+
+		ginkgo.It("should create a second resource and list both", func() {
+			body, _ := json.Marshal(spec.CreatePayload)
+			req := spec.authedRequest("POST", spec.BaseURL()+"/"+spec.Resource, body)
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusCreated))
+
+			listReq := spec.authedRequest("GET", spec.BaseURL()+"/"+spec.Resource, nil)
+			listResp, err := client.Do(listReq)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer listResp.Body.Close()
+
+			var list []map[string]interface{}
+			gomega.Expect(json.NewDecoder(listResp.Body).Decode(&list)).To(gomega.Succeed())
+			gomega.Expect(len(list)).To(gomega.BeNumerically(">=", 2))
+		})
+
+		ginkgo.It("should delete the resource", func() {
+			req := spec.authedRequest("DELETE", spec.BaseURL()+"/"+spec.Resource+"/"+createdID, nil)
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusNoContent))
+		})
+
+		ginkgo.It("should 404 on the deleted resource", func() {
+			req := spec.authedRequest("GET", spec.BaseURL()+"/"+spec.Resource+"/"+createdID, nil)
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should reject requests with no API key", func() {
+			req, _ := http.NewRequest("GET", spec.BaseURL()+"/"+spec.Resource, nil)
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusUnauthorized))
+		})
+
+		ginkgo.It("should reject requests with a bad API key", func() {
+			req, _ := http.NewRequest("GET", spec.BaseURL()+"/"+spec.Resource, nil)
+			req.Header.Set("X-Api-Key", "not-a-real-key")
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusUnauthorized))
+		})
+
+		ginkgo.It("should reject malformed JSON with 400", func() {
+			req := spec.authedRequest("POST", spec.BaseURL()+"/"+spec.Resource, []byte(`{not json`))
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusBadRequest))
+		})
+
+		ginkgo.It("should reject the wrong Content-Type with 415", func() {
+			body, _ := json.Marshal(spec.CreatePayload)
+			req, _ := http.NewRequest("POST", spec.BaseURL()+"/"+spec.Resource, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "text/plain")
+			if spec.Auth == AuthAPIKey {
+				req.Header.Set("X-Api-Key", spec.APIKey())
+			}
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusUnsupportedMediaType))
+		})
+
+		ginkgo.It("should reject an oversized body with 413", func() {
+			oversized := strings.Repeat("x", spec.maxBodyBytes()+1)
+			body, _ := json.Marshal(map[string]interface{}{"padding": oversized})
+			req := spec.authedRequest("POST", spec.BaseURL()+"/"+spec.Resource, body)
+			resp, err := client.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusRequestEntityTooLarge))
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/apifyhost/apify/e2e/crudgen"
+)
+
+var _ = Describe("Generated CRUD Suites", Ordered, func() {
+	var env *TestEnv
+
+	BeforeAll(func() {
+		specDir, err := os.MkdirTemp("", "apify-crudgen-test")
+		Expect(err).NotTo(HaveOccurred())
+		specPath := filepath.Join(specDir, "widgets.yaml")
+		Expect(os.WriteFile(specPath, []byte(`openapi: "3.0.0"
+info:
+  title: "Widgets"
+  version: "1.0.0"
+x-table-schemas:
+  - table_name: "widgets"
+    columns:
+      - { name: "id", column_type: "INTEGER", nullable: false, primary_key: true, unique: false, auto_increment: true, default_value: null }
+      - { name: "name", column_type: "TEXT", nullable: false, primary_key: false, unique: false, auto_increment: false }
+    indexes: []
+paths:
+  /widgets:
+    get:
+      x-table-name: "widgets"
+      responses: { "200": { description: "ok" } }
+    post:
+      x-table-name: "widgets"
+      responses: { "201": { description: "created" } }
+  /widgets/{id}:
+    get:
+      x-table-name: "widgets"
+      responses: { "200": { description: "ok" }, "404": { description: "not found" } }
+    put:
+      x-table-name: "widgets"
+      responses: { "200": { description: "ok" } }
+    delete:
+      x-table-name: "widgets"
+      responses: { "204": { description: "deleted" } }
+`), 0644)).To(Succeed())
+
+		env = StartTestEnv(map[string]string{"widgets-api": specPath})
+	})
+
+	AfterAll(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	crudgen.RunCRUDSuite(crudgen.CRUDSpec{
+		Resource:      "widgets",
+		BaseURL:       func() string { return env.BaseURL },
+		APIKey:        func() string { return env.APIKey },
+		CreatePayload: map[string]interface{}{"name": "gizmo"},
+		UpdatePayload: map[string]interface{}{"name": "gadget"},
+		ExpectedFields: map[string]OmegaMatcher{
+			"name": Equal("gizmo"),
+		},
+		Auth: crudgen.AuthAPIKey,
+	})
+})
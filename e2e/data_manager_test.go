@@ -76,7 +76,7 @@ var _ = Describe("Data Manager API", func() {
 		Expect(err).NotTo(HaveOccurred())
 		// TableSchema uses camelCase JSON serialization
 		Expect(schema["tableName"]).To(Equal("users"))
-		
+
 		columns := schema["columns"].([]interface{})
 		Expect(len(columns)).To(Equal(4)) // id, name, email, age
 	})
@@ -109,7 +109,7 @@ var _ = Describe("Data Manager API", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(len(users)).To(Equal(1))
 		Expect(users[0]["name"]).To(Equal("Alice"))
-		
+
 		// Get ID for update/delete
 		// ID might be float64 due to JSON decoding
 		idVal := users[0]["id"]
@@ -150,4 +150,220 @@ var _ = Describe("Data Manager API", func() {
 		json.NewDecoder(resp.Body).Decode(&finalUsers)
 		Expect(len(finalUsers)).To(Equal(0))
 	})
+
+	Describe("Bulk and Transactional Operations", func() {
+		It("should bulk insert rows and report per-row status", func() {
+			rows := []map[string]interface{}{
+				{"name": "Bob", "email": "bob@example.com", "age": 22},
+				{"name": "Carol", "email": "carol@example.com", "age": 27},
+			}
+			body, _ := json.Marshal(rows)
+			resp, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/users/bulk", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+			var result struct {
+				Results []struct {
+					OK bool  `json:"ok"`
+					ID int64 `json:"id"`
+				} `json:"results"`
+			}
+			err = json.NewDecoder(resp.Body).Decode(&result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Results).To(HaveLen(2))
+			for _, r := range result.Results {
+				Expect(r.OK).To(BeTrue())
+				Expect(r.ID).To(BeNumerically(">", 0))
+			}
+		})
+
+		It("should bulk update rows matching a where filter", func() {
+			ops := []map[string]interface{}{
+				{"where": map[string]interface{}{"name": "Bob"}, "set": map[string]interface{}{"age": 23}},
+			}
+			body, _ := json.Marshal(ops)
+			req, err := http.NewRequest(http.MethodPatch, env.CPBaseURL+"/apify/admin/data/user-ds/users/bulk", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("should bulk delete rows matching a where filter", func() {
+			body, _ := json.Marshal(map[string]interface{}{"where": map[string]interface{}{"name": "Carol"}})
+			req, err := http.NewRequest(http.MethodDelete, env.CPBaseURL+"/apify/admin/data/user-ds/users/bulk", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("should roll back a transaction when one operation fails", func() {
+			tx := map[string]interface{}{
+				"ops": []map[string]interface{}{
+					{"type": "insert", "table": "users", "row": map[string]interface{}{"name": "Dave", "age": 40}},
+					// A column the users table does not have should fail and roll back the whole tx.
+					{"type": "insert", "table": "users", "row": map[string]interface{}{"no_such_column": "x"}},
+				},
+			}
+			body, _ := json.Marshal(tx)
+			resp, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/tx", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+
+			// Verify Dave was rolled back, not left partially committed.
+			queryPayload := map[string]interface{}{"where": map[string]interface{}{"name": "Dave"}}
+			qBody, _ := json.Marshal(queryPayload)
+			resp2, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/users/query", "application/json", bytes.NewBuffer(qBody))
+			Expect(err).NotTo(HaveOccurred())
+			var rows []map[string]interface{}
+			json.NewDecoder(resp2.Body).Decode(&rows)
+			Expect(rows).To(BeEmpty())
+		})
+
+		It("should support mixed-table transactions", func() {
+			cmd := exec.Command("sqlite3", userDBPath, "CREATE TABLE orders (id INTEGER PRIMARY KEY AUTOINCREMENT, user_name TEXT, total REAL);")
+			Expect(cmd.Run()).NotTo(HaveOccurred())
+
+			tx := map[string]interface{}{
+				"ops": []map[string]interface{}{
+					{"type": "insert", "table": "users", "row": map[string]interface{}{"name": "Erin", "email": "erin@example.com", "age": 33}},
+					{"type": "insert", "table": "orders", "row": map[string]interface{}{"user_name": "Erin", "total": 42.5}},
+				},
+			}
+			body, _ := json.Marshal(tx)
+			resp, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/tx", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			var result struct {
+				Results []map[string]interface{} `json:"results"`
+			}
+			err = json.NewDecoder(resp.Body).Decode(&result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Results).To(HaveLen(2))
+		})
+	})
+
+	Describe("Query DSL", func() {
+		BeforeEach(func() {
+			for _, u := range []map[string]interface{}{
+				{"name": "Alice", "email": "alice@example.com", "age": 30},
+				{"name": "Bob", "email": "bob@example.com", "age": 22},
+				{"name": "Carol", "email": "carol@example.com", "age": 45},
+			} {
+				body, _ := json.Marshal(u)
+				resp, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/users", "application/json", bytes.NewBuffer(body))
+				Expect(err).NotTo(HaveOccurred())
+				resp.Body.Close()
+			}
+		})
+
+		It("should support operator objects like $gt and $lte", func() {
+			queryPayload := map[string]interface{}{
+				"where": map[string]interface{}{
+					"age": map[string]interface{}{"$gt": 20, "$lte": 40},
+				},
+				"orderBy": []map[string]interface{}{{"column": "age", "dir": "asc"}},
+			}
+			body, _ := json.Marshal(queryPayload)
+			resp, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/users/query", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			var envelope struct {
+				Data []map[string]interface{} `json:"data"`
+				Page map[string]interface{}   `json:"page"`
+			}
+			err = json.NewDecoder(resp.Body).Decode(&envelope)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(envelope.Data).To(HaveLen(2))
+			Expect(envelope.Data[0]["name"]).To(Equal("Bob"))
+		})
+
+		It("should support $or trees and column projection", func() {
+			queryPayload := map[string]interface{}{
+				"select": []string{"name", "age"},
+				"where": map[string]interface{}{
+					"$or": []map[string]interface{}{
+						{"name": "Alice"},
+						{"name": "Carol"},
+					},
+				},
+				"orderBy": []map[string]interface{}{{"column": "name", "dir": "asc"}},
+			}
+			body, _ := json.Marshal(queryPayload)
+			resp, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/users/query", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			var envelope struct {
+				Data []map[string]interface{} `json:"data"`
+			}
+			json.NewDecoder(resp.Body).Decode(&envelope)
+			Expect(envelope.Data).To(HaveLen(2))
+			Expect(envelope.Data[0]).NotTo(HaveKey("email"))
+			Expect(envelope.Data[0]["name"]).To(Equal("Alice"))
+		})
+
+		It("should paginate with limit and a cursor", func() {
+			queryPayload := map[string]interface{}{
+				"orderBy": []map[string]interface{}{{"column": "name", "dir": "asc"}},
+				"limit":   2,
+			}
+			body, _ := json.Marshal(queryPayload)
+			resp, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/users/query", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			var envelope struct {
+				Data []map[string]interface{} `json:"data"`
+				Page struct {
+					Limit      int    `json:"limit"`
+					NextCursor string `json:"nextCursor"`
+				} `json:"page"`
+			}
+			json.NewDecoder(resp.Body).Decode(&envelope)
+			Expect(envelope.Data).To(HaveLen(2))
+			Expect(envelope.Page.NextCursor).NotTo(BeEmpty())
+
+			// Follow the cursor for the next page.
+			queryPayload2 := map[string]interface{}{
+				"orderBy": []map[string]interface{}{{"column": "name", "dir": "asc"}},
+				"limit":   2,
+				"cursor":  envelope.Page.NextCursor,
+			}
+			body2, _ := json.Marshal(queryPayload2)
+			resp2, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/users/query", "application/json", bytes.NewBuffer(body2))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp2.Body.Close()
+			var envelope2 struct {
+				Data []map[string]interface{} `json:"data"`
+			}
+			json.NewDecoder(resp2.Body).Decode(&envelope2)
+			Expect(envelope2.Data).To(HaveLen(1))
+		})
+
+		It("should reject unknown operators", func() {
+			queryPayload := map[string]interface{}{
+				"where": map[string]interface{}{
+					"age": map[string]interface{}{"$shell": "1=1"},
+				},
+			}
+			body, _ := json.Marshal(queryPayload)
+			resp, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/users/query", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
 })
@@ -0,0 +1,115 @@
+package e2e_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Datasource Change-Data-Capture Stream", func() {
+	var (
+		env        *TestEnv
+		client     *http.Client
+		userDBPath string
+	)
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{})
+		client = &http.Client{Timeout: 10 * time.Second}
+
+		userDBPath = filepath.Join(env.TmpDir, "user.db")
+		cmd := exec.Command("sqlite3", userDBPath, "CREATE TABLE users (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT);")
+		Expect(cmd.Run()).NotTo(HaveOccurred())
+
+		datasourceConfig := map[string]interface{}{
+			"name":   "user-ds",
+			"config": map[string]interface{}{"driver": "sqlite", "database": userDBPath},
+		}
+		body, _ := json.Marshal(datasourceConfig)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/datasources", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+		resp.Body.Close()
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should emit an insert event observed on the watch stream", func() {
+		req, err := http.NewRequest("GET", env.CPBaseURL+"/apify/admin/data/user-ds/users/watch", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", "text/event-stream")
+
+		streamResp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer streamResp.Body.Close()
+		Expect(streamResp.StatusCode).To(Equal(http.StatusOK))
+		Expect(streamResp.Header.Get("Content-Type")).To(ContainSubstring("text/event-stream"))
+
+		events := make(chan map[string]interface{}, 8)
+		go func() {
+			scanner := bufio.NewScanner(streamResp.Body)
+			for scanner.Scan() {
+				line := scanner.Text()
+				if strings.HasPrefix(line, "data: ") {
+					var evt map[string]interface{}
+					if json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &evt) == nil {
+						events <- evt
+					}
+				}
+			}
+		}()
+
+		// Give the subscription time to register before mutating.
+		time.Sleep(500 * time.Millisecond)
+
+		user := map[string]interface{}{"name": "Watched"}
+		body, _ := json.Marshal(user)
+		createResp, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/users", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		createResp.Body.Close()
+
+		Eventually(events, "10s").Should(Receive(SatisfyAll(
+			HaveKeyWithValue("op", "insert"),
+			HaveKey("row"),
+			HaveKey("ts"),
+			HaveKey("lsn"),
+		)))
+	})
+
+	It("should replay events since a given lsn", func() {
+		// Generate a change before any subscriber connects.
+		body, _ := json.Marshal(map[string]interface{}{"name": "Before"})
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/data/user-ds/users", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		req, err := http.NewRequest("GET", env.CPBaseURL+"/apify/admin/data/user-ds/users/watch?since=0", nil)
+		Expect(err).NotTo(HaveOccurred())
+		streamResp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer streamResp.Body.Close()
+		Expect(streamResp.StatusCode).To(Equal(http.StatusOK))
+
+		scanner := bufio.NewScanner(streamResp.Body)
+		Eventually(func() bool {
+			for scanner.Scan() {
+				if strings.HasPrefix(scanner.Text(), "data: ") {
+					return true
+				}
+			}
+			return false
+		}, "10s").Should(BeTrue())
+	})
+})
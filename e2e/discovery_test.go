@@ -0,0 +1,141 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Meta-Plane Discovery and ETags", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	submitSpec := func(name, content string) *http.Response {
+		payload := map[string]string{
+			"name":    name,
+			"version": "1.0.0",
+			"spec":    content,
+		}
+		body, _ := json.Marshal(payload)
+		resp, err := client.Post(env.CPBaseURL+"/_meta/apis", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	widgetsSpec := `
+openapi: 3.0.0
+info:
+  title: Widgets API
+  version: 1.0.0
+paths:
+  /widgets:
+    get:
+      operationId: listWidgets
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Widget'
+components:
+  schemas:
+    Widget:
+      type: object
+      properties:
+        id:
+          type: integer
+      x-table-schema:
+        tableName: widgets
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+`
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{})
+		client = &http.Client{Timeout: 10 * time.Second}
+
+		resp := submitSpec("widgets-api", widgetsSpec)
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+		resp.Body.Close()
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should return a discovery index of registered APIs with name, version, hash, and counts", func() {
+		resp, err := client.Get(env.CPBaseURL + "/_meta/apis")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Cache-Control")).To(ContainSubstring("no-cache"))
+		Expect(resp.Header.Get("ETag")).NotTo(BeEmpty())
+
+		var index []map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&index)).To(Succeed())
+		Expect(index).NotTo(BeEmpty())
+
+		var widgets map[string]interface{}
+		for _, api := range index {
+			if api["name"] == "widgets-api" {
+				widgets = api
+			}
+		}
+		Expect(widgets).NotTo(BeNil())
+		Expect(widgets).To(HaveKey("version"))
+		Expect(widgets).To(HaveKey("specHash"))
+		Expect(widgets).To(HaveKey("tableCount"))
+		Expect(widgets).To(HaveKey("endpointCount"))
+		Expect(widgets).To(HaveKey("lastReloadAt"))
+	})
+
+	It("should serve the canonical spec at /_meta/apis/{name} with a strong ETag and honor If-None-Match", func() {
+		resp, err := client.Get(env.CPBaseURL + "/_meta/apis/widgets-api")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		etag := resp.Header.Get("ETag")
+		Expect(etag).NotTo(BeEmpty())
+
+		req, err := http.NewRequest("GET", env.CPBaseURL+"/_meta/apis/widgets-api", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("If-None-Match", etag)
+
+		cached, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer cached.Body.Close()
+		Expect(cached.StatusCode).To(Equal(http.StatusNotModified))
+	})
+
+	It("should not emit a reload event when a resubmitted spec is byte-identical", func() {
+		first, err := client.Get(env.CPBaseURL + "/_meta/apis/widgets-api")
+		Expect(err).NotTo(HaveOccurred())
+		firstETag := first.Header.Get("ETag")
+		first.Body.Close()
+
+		resp := submitSpec("widgets-api", widgetsSpec)
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+		resp.Body.Close()
+
+		time.Sleep(2 * time.Second)
+
+		second, err := client.Get(env.CPBaseURL + "/_meta/apis/widgets-api")
+		Expect(err).NotTo(HaveOccurred())
+		defer second.Body.Close()
+		Expect(second.Header.Get("ETag")).To(Equal(firstETag))
+	})
+})
@@ -1,21 +1,23 @@
 package e2e_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
-	"encoding/json"
-	"bytes"
-	"strconv"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 	"gopkg.in/yaml.v3"
 )
 
@@ -25,18 +27,33 @@ func TestE2E(t *testing.T) {
 }
 
 type TestEnv struct {
-	ServerCmd  *exec.Cmd
-	CPCmd      *exec.Cmd
-	TmpDir     string
-	BaseURL    string
-	CPBaseURL  string
-	APIKey     string
-	ConfigFile string
-	DBFile     string
+	ServerCmd   *exec.Cmd
+	CPCmd       *exec.Cmd
+	TmpDir      string
+	BaseURL     string
+	CPBaseURL   string
+	CPPort      string
+	APIKey      string
+	ConfigFile  string
+	DBFile      string
 	MetricsPort string
+	// LogBuffer mirrors the data plane's stdout, in addition to GinkgoWriter,
+	// so specs can assert on emitted access-log lines (e.g. request ID
+	// correlation) without re-spawning the process themselves.
+	LogBuffer *bytes.Buffer
 }
 
-func StartTestEnv(specFiles map[string]string) *TestEnv {
+// StartTestEnv boots a Control Plane + Data Plane pair and registers the
+// given named OpenAPI specs against it. An optional upstreamOverrides map
+// points specific API names at a mock upstreamsvr.New() server instead of
+// whatever backend the spec itself declares, for contract tests that need
+// to assert exactly what the data plane forwards.
+func StartTestEnv(specFiles map[string]string, upstreamOverrides ...map[string]*ghttp.Server) *TestEnv {
+	var overrides map[string]*ghttp.Server
+	if len(upstreamOverrides) > 0 {
+		overrides = upstreamOverrides[0]
+	}
+
 	var err error
 	env := &TestEnv{}
 
@@ -163,10 +180,11 @@ modules:
 	}, 60*time.Second, 1*time.Second).Should(Succeed())
 
 	// Start Data Plane
+	env.LogBuffer = &bytes.Buffer{}
 	env.ServerCmd = exec.Command("cargo", "run", "--bin", "apify", "--", "--data-plane", "--config", env.ConfigFile)
 	env.ServerCmd.Dir = projectRoot
 	env.ServerCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+env.DBFile, "APIFY_CONFIG_POLL_INTERVAL=1")
-	env.ServerCmd.Stdout = GinkgoWriter
+	env.ServerCmd.Stdout = io.MultiWriter(GinkgoWriter, env.LogBuffer)
 	env.ServerCmd.Stderr = GinkgoWriter
 
 	err = env.ServerCmd.Start()
@@ -208,6 +226,10 @@ modules:
 			}
 		}
 
+		if srv, ok := overrides[name]; ok {
+			specObj["x-upstream-url"] = srv.URL()
+		}
+
 		payload := map[string]interface{}{
 			"name":    name,
 			"version": "1.0.0",
@@ -275,4 +297,3 @@ func indent(s string, n int) string {
 	}
 	return strings.Join(lines, "\n")
 }
-
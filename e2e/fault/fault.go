@@ -0,0 +1,227 @@
+// Package fault is a toxiproxy-style HTTP shim placed between the data plane
+// and a mocked upstream, letting Ginkgo specs toggle latency, connection
+// resets, partial responses and outright downtime to exercise the data
+// plane's retry policy, timeouts and circuit breaker.
+package fault
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Event records one proxied (or rejected) request for timeline assertions.
+type Event struct {
+	At       time.Time
+	Duration time.Duration
+	Outcome  string // "ok", "reset", "partial", "down", "error"
+}
+
+// Proxy is an HTTP reverse proxy with programmable fault injection. It's safe
+// to toggle faults concurrently with in-flight requests from the test.
+type Proxy struct {
+	server *httptest.Server
+	target *url.URL
+
+	mu           sync.Mutex
+	latencyMs    int
+	jitterMs     int
+	down         bool
+	resetConns   bool
+	partial      bool
+	bandwidthBps int
+
+	events []Event
+}
+
+// NewProxy starts a fault-injecting proxy in front of upstreamURL. Point the
+// data plane's x-upstream-url (or equivalent override) at Proxy.URL().
+func NewProxy(upstreamURL string) *Proxy {
+	target, err := url.Parse(upstreamURL)
+	if err != nil {
+		panic(err)
+	}
+
+	p := &Proxy{target: target}
+	p.server = httptest.NewServer(http.HandlerFunc(p.handle))
+	return p
+}
+
+// URL is the address tests should configure as the upstream.
+func (p *Proxy) URL() string { return p.server.URL }
+
+// Close shuts down the proxy's listener.
+func (p *Proxy) Close() { p.server.Close() }
+
+// AddLatency delays every proxied request by ms plus a random amount below
+// jitterMs. Pass 0, 0 to disable.
+func (p *Proxy) AddLatency(ms, jitterMs int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.latencyMs = ms
+	p.jitterMs = jitterMs
+}
+
+// SetBandwidthCap throttles the response body to at most bps bytes/second.
+// A cap of 0 disables throttling.
+func (p *Proxy) SetBandwidthCap(bps int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.bandwidthBps = bps
+}
+
+// SetDown makes every request fail to connect, as if the upstream were
+// entirely unreachable.
+func (p *Proxy) SetDown(down bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.down = down
+}
+
+// SetResetConnections makes every request's connection close mid-response
+// with no body, simulating an abrupt upstream crash.
+func (p *Proxy) SetResetConnections(reset bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.resetConns = reset
+}
+
+// SetPartialResponses truncates the upstream's response body roughly in
+// half before closing the connection.
+func (p *Proxy) SetPartialResponses(partial bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.partial = partial
+}
+
+// Events returns a snapshot of every request handled so far, in order, for
+// asserting timelines like "3 retries with exponential backoff".
+func (p *Proxy) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]Event, len(p.events))
+	copy(out, p.events)
+	return out
+}
+
+func (p *Proxy) snapshot() (latencyMs, jitterMs int, down, resetConns, partial bool, bandwidthBps int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.latencyMs, p.jitterMs, p.down, p.resetConns, p.partial, p.bandwidthBps
+}
+
+func (p *Proxy) record(start time.Time, outcome string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, Event{At: start, Duration: time.Since(start), Outcome: outcome})
+}
+
+func (p *Proxy) handle(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	latencyMs, jitterMs, down, resetConns, partial, bandwidthBps := p.snapshot()
+
+	if latencyMs > 0 || jitterMs > 0 {
+		delay := time.Duration(latencyMs) * time.Millisecond
+		if jitterMs > 0 {
+			delay += time.Duration(rand.Intn(jitterMs)) * time.Millisecond
+		}
+		time.Sleep(delay)
+	}
+
+	if down {
+		// Hijack and close with no response at all, rather than writing a
+		// status code, so callers see a connection failure like a real
+		// downed upstream rather than a valid HTTP error response.
+		p.hijackAndClose(w)
+		p.record(start, "down")
+		return
+	}
+
+	req, err := http.NewRequest(r.Method, p.target.String()+r.URL.Path, r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		p.record(start, "error")
+		return
+	}
+	req.Header = r.Header
+	req.URL.RawQuery = r.URL.RawQuery
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		p.record(start, "error")
+		return
+	}
+	defer resp.Body.Close()
+
+	if resetConns {
+		p.hijackAndClose(w)
+		p.record(start, "reset")
+		return
+	}
+
+	for k, vs := range resp.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	if partial {
+		body = body[:len(body)/2]
+	}
+
+	if bandwidthBps > 0 {
+		p.writeThrottled(w, body, bandwidthBps)
+	} else {
+		w.Write(body)
+	}
+
+	if partial {
+		p.hijackAndClose(w)
+		p.record(start, "partial")
+		return
+	}
+
+	p.record(start, "ok")
+}
+
+func (p *Proxy) writeThrottled(w http.ResponseWriter, body []byte, bps int) {
+	flusher, _ := w.(http.Flusher)
+	chunkSize := bps / 10
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	for len(body) > 0 {
+		n := chunkSize
+		if n > len(body) {
+			n = len(body)
+		}
+		w.Write(body[:n])
+		if flusher != nil {
+			flusher.Flush()
+		}
+		body = body[n:]
+		if len(body) > 0 {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+}
+
+func (p *Proxy) hijackAndClose(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return
+	}
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	buf.Flush()
+	conn.Close()
+}
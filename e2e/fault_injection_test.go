@@ -0,0 +1,151 @@
+package e2e_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/apifyhost/apify/e2e/fault"
+)
+
+var _ = Describe("Upstream Fault Injection", func() {
+	var (
+		env      *TestEnv
+		upstream *ghttp.Server
+		proxy    *fault.Proxy
+		client   *http.Client
+	)
+
+	BeforeEach(func() {
+		upstream = ghttp.NewServer()
+		proxy = fault.NewProxy(upstream.URL())
+		client = &http.Client{Timeout: 15 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+		proxy.Close()
+		upstream.Close()
+	})
+
+	startWithFaultyUpstream := func() {
+		specDir, err := os.MkdirTemp("", "apify-fault-test")
+		Expect(err).NotTo(HaveOccurred())
+		specPath := filepath.Join(specDir, "widgets.yaml")
+		spec := fmt.Sprintf(`openapi: "3.0.0"
+info:
+  title: "Widgets"
+  version: "1.0.0"
+x-upstream-url: %s
+paths:
+  /widgets:
+    get:
+      x-proxy: true
+      x-retry:
+        attempts: 3
+        backoff_ms: 100
+        backoff_max_ms: 800
+      x-timeout-ms: 500
+      x-circuit-breaker:
+        error_threshold: 3
+        cooldown_ms: 1000
+      responses:
+        "200":
+          description: "ok"
+`, proxy.URL())
+		Expect(os.WriteFile(specPath, []byte(spec), 0644)).To(Succeed())
+
+		env = StartTestEnv(map[string]string{"widgets-api": specPath})
+	}
+
+	It("should retry with exponential backoff and eventually succeed once downtime clears", func() {
+		startWithFaultyUpstream()
+		proxy.SetDown(true)
+
+		go func() {
+			time.Sleep(250 * time.Millisecond)
+			proxy.SetDown(false)
+			upstream.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/widgets"),
+					ghttp.RespondWith(http.StatusOK, `[]`),
+				),
+			)
+		}()
+
+		req, err := http.NewRequest("GET", env.BaseURL+"/widgets", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		events := proxy.Events()
+		Expect(len(events)).To(BeNumerically(">=", 2))
+	})
+
+	It("should return 504 when the upstream never responds within the configured timeout", func() {
+		startWithFaultyUpstream()
+		proxy.AddLatency(5000, 0)
+
+		req, err := http.NewRequest("GET", env.BaseURL+"/widgets", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusGatewayTimeout))
+	})
+
+	It("should return 502 when the upstream resets the connection", func() {
+		startWithFaultyUpstream()
+		proxy.SetResetConnections(true)
+		upstream.AppendHandlers(
+			ghttp.RespondWith(http.StatusOK, `[]`),
+		)
+
+		req, err := http.NewRequest("GET", env.BaseURL+"/widgets", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusBadGateway))
+	})
+
+	It("should open the circuit breaker after repeated failures and fail fast without hitting the upstream", func() {
+		startWithFaultyUpstream()
+		proxy.SetDown(true)
+
+		req, err := http.NewRequest("GET", env.BaseURL+"/widgets", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+
+		for i := 0; i < 4; i++ {
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+		}
+
+		eventsBefore := len(proxy.Events())
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+
+		Expect(len(proxy.Events())).To(Equal(eventsBefore), "a tripped breaker should short-circuit before reaching the upstream proxy")
+	})
+})
@@ -0,0 +1,117 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filter Expression Queries on Admin List Endpoints", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		env, client, err = SetupControlPlaneEnv()
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, l := range []map[string]interface{}{
+			{"name": "listener-1", "port": 9001, "ip": "0.0.0.0", "protocol": "HTTP"},
+			{"name": "listener-2", "port": 9002, "ip": "0.0.0.0", "protocol": "HTTP"},
+		} {
+			body, _ := json.Marshal(l)
+			resp, err := client.Post(env.CPBaseURL+"/apify/admin/listeners", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+		}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should filter listeners by a simple equality expression", func() {
+		q := url.QueryEscape(`name == "listener-1"`)
+		resp, err := client.Get(env.CPBaseURL + "/apify/admin/listeners?filter=" + q)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var results []map[string]interface{}
+		Expect(decodeJSON(resp, &results)).To(Succeed())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0]["name"]).To(Equal("listener-1"))
+	})
+
+	It("should filter with an and-joined comparison expression", func() {
+		q := url.QueryEscape(`protocol == "HTTP" and port >= 9002`)
+		resp, err := client.Get(env.CPBaseURL + "/apify/admin/listeners?filter=" + q)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var results []map[string]interface{}
+		Expect(decodeJSON(resp, &results)).To(Succeed())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0]["name"]).To(Equal("listener-2"))
+	})
+
+	It("should return 400 with a caret-pointed error on invalid syntax", func() {
+		q := url.QueryEscape(`name == `)
+		resp, err := client.Get(env.CPBaseURL + "/apify/admin/listeners?filter=" + q)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+		var errBody map[string]interface{}
+		Expect(decodeJSON(resp, &errBody)).To(Succeed())
+		Expect(errBody["error"]).NotTo(BeEmpty())
+		Expect(errBody["error"]).To(ContainSubstring("^"))
+	})
+
+	It("should evaluate unknown fields to null rather than panicking", func() {
+		q := url.QueryEscape(`nonexistent_field == "x"`)
+		resp, err := client.Get(env.CPBaseURL + "/apify/admin/listeners?filter=" + q)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var results []map[string]interface{}
+		Expect(decodeJSON(resp, &results)).To(Succeed())
+		Expect(results).To(BeEmpty())
+	})
+
+	It("should support the filter expression on the APIs list endpoint too", func() {
+		apiConfig := map[string]interface{}{
+			"name":    "test-api",
+			"version": "1.0.0",
+			"spec": map[string]interface{}{
+				"openapi": "3.0.0",
+				"info":    map[string]interface{}{"title": "Test API", "version": "1.0.0"},
+				"paths":   map[string]interface{}{},
+			},
+		}
+		body, _ := json.Marshal(apiConfig)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/apis", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		q := url.QueryEscape(`spec.info.title matches "^Test"`)
+		resp, err = client.Get(env.CPBaseURL + "/apify/admin/apis?filter=" + q)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var results []map[string]interface{}
+		Expect(decodeJSON(resp, &results)).To(Succeed())
+		Expect(len(results)).To(BeNumerically(">=", 1))
+	})
+})
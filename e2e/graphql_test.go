@@ -0,0 +1,203 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GraphQL Query Endpoint", func() {
+	var (
+		env     *TestEnv
+		baseURL string
+		apiKey  string
+		client  *http.Client
+	)
+
+	startEnv := func() {
+		env = StartTestEnv(map[string]string{
+			"orders": "examples/relations/config/openapi/orders.yaml",
+			"users":  "examples/relations/config/openapi/users.yaml",
+		})
+		baseURL = env.BaseURL
+		apiKey = env.APIKey
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	stopEnv := func() {
+		if env != nil {
+			env.Stop()
+		}
+	}
+
+	graphqlRequest := func(query string, variables map[string]interface{}) map[string]interface{} {
+		payload := map[string]interface{}{"query": query}
+		if variables != nil {
+			payload["variables"] = variables
+		}
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+
+		req, err := http.NewRequest("POST", baseURL+"/apify/graphql", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", apiKey)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var result map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&result)).To(Succeed())
+		return result
+	}
+
+	Describe("Schema Introspection", func() {
+		BeforeEach(startEnv)
+		AfterEach(stopEnv)
+
+		It("should expose the Order and User types derived from the OpenAPI models", func() {
+			result := graphqlRequest(`{ __schema { types { name } } }`, nil)
+			Expect(result).NotTo(HaveKey("errors"))
+
+			data := result["data"].(map[string]interface{})
+			schema := data["__schema"].(map[string]interface{})
+			types := schema["types"].([]interface{})
+
+			var names []string
+			for _, t := range types {
+				names = append(names, t.(map[string]interface{})["name"].(string))
+			}
+			Expect(names).To(ContainElement("Order"))
+			Expect(names).To(ContainElement("OrderItem"))
+			Expect(names).To(ContainElement("User"))
+		})
+	})
+
+	Describe("hasMany field resolution (Orders with Items)", Ordered, func() {
+		BeforeAll(startEnv)
+		AfterAll(stopEnv)
+
+		var orderID int64
+
+		It("should create an order via a GraphQL mutation", func() {
+			result := graphqlRequest(`
+				mutation {
+					createOrder(input: {customerName: "GraphQL Customer", total: 59.98, status: "pending",
+						items: [{productName: "Widget", quantity: 2, price: 29.99}]}) {
+						id
+						items { id productName quantity }
+					}
+				}`, nil)
+			Expect(result).NotTo(HaveKey("errors"))
+
+			data := result["data"].(map[string]interface{})
+			created := data["createOrder"].(map[string]interface{})
+			Expect(created["id"]).NotTo(BeNil())
+
+			id, ok := created["id"].(float64)
+			Expect(ok).To(BeTrue())
+			orderID = int64(id)
+
+			items := created["items"].([]interface{})
+			Expect(items).To(HaveLen(1))
+		})
+
+		It("should resolve the order's items field without an N+1 query per item", func() {
+			query := fmt.Sprintf(`{ order(id: %d) { id customerName items { productName quantity price } } }`, orderID)
+			result := graphqlRequest(query, nil)
+			Expect(result).NotTo(HaveKey("errors"))
+
+			data := result["data"].(map[string]interface{})
+			order := data["order"].(map[string]interface{})
+			Expect(order["customerName"]).To(Equal("GraphQL Customer"))
+
+			items := order["items"].([]interface{})
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].(map[string]interface{})["productName"]).To(Equal("Widget"))
+		})
+
+		It("should batch list-resolver item fetches across multiple orders via the dataloader", func() {
+			// A second order exercises the dataloader batching path: listing
+			// both orders' items should still only need one round trip to
+			// resolve, which from the client's perspective means both
+			// orders' items come back populated in a single query.
+			graphqlRequest(`
+				mutation {
+					createOrder(input: {customerName: "Second Customer", total: 10.0, status: "pending",
+						items: [{productName: "Gadget", quantity: 1, price: 10.0}]}) {
+						id
+					}
+				}`, nil)
+
+			result := graphqlRequest(`{ orders { id customerName items { productName } } }`, nil)
+			Expect(result).NotTo(HaveKey("errors"))
+
+			data := result["data"].(map[string]interface{})
+			orders := data["orders"].([]interface{})
+			Expect(len(orders)).To(BeNumerically(">=", 2))
+			for _, o := range orders {
+				order := o.(map[string]interface{})
+				Expect(order["items"]).NotTo(BeNil())
+			}
+		})
+
+		It("should delete the order via a GraphQL mutation", func() {
+			query := fmt.Sprintf(`mutation { deleteOrder(id: %d) { id } }`, orderID)
+			result := graphqlRequest(query, nil)
+			Expect(result).NotTo(HaveKey("errors"))
+		})
+	})
+
+	Describe("belongsTo field resolution (Users with Profiles)", func() {
+		BeforeEach(startEnv)
+		AfterEach(stopEnv)
+
+		It("should resolve a user's profile and the profile's parent user", func() {
+			createResult := graphqlRequest(`
+				mutation {
+					createUser(input: {username: "gqluser", email: "gql@example.com",
+						profile: {fullName: "GraphQL User", bio: "testing"}}) {
+						id
+					}
+				}`, nil)
+			Expect(createResult).NotTo(HaveKey("errors"))
+
+			data := createResult["data"].(map[string]interface{})
+			userID := int64(data["createUser"].(map[string]interface{})["id"].(float64))
+
+			query := fmt.Sprintf(`{ user(id: %d) { username profile { bio user { username } } } }`, userID)
+			result := graphqlRequest(query, nil)
+			Expect(result).NotTo(HaveKey("errors"))
+
+			queried := result["data"].(map[string]interface{})["user"].(map[string]interface{})
+			profile := queried["profile"].(map[string]interface{})
+			Expect(profile["bio"]).To(Equal("testing"))
+			Expect(profile["user"].(map[string]interface{})["username"]).To(Equal("gqluser"))
+		})
+	})
+
+	Describe("Per-datasource GraphQL endpoint", func() {
+		BeforeEach(startEnv)
+		AfterEach(stopEnv)
+
+		It("should also accept queries at /apify/{ds}/graphql", func() {
+			req, err := http.NewRequest("POST", baseURL+"/apify/default/graphql",
+				bytes.NewBufferString(`{"query": "{ orders { id } }"}`))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("X-Api-Key", apiKey)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+	})
+})
@@ -14,29 +14,8 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-// TestEnv holds the test environment configuration
-type TestEnv struct {
-	TmpDir      string
-	ConfigFile  string
-	DBFile      string
-	CPBaseURL   string
-	CPPort      string
-	MetricsPort string
-	CPCmd       *exec.Cmd
-}
-
-// Stop stops the control plane process
-func (e *TestEnv) Stop() {
-	if e.CPCmd != nil && e.CPCmd.Process != nil {
-		e.CPCmd.Process.Kill()
-		e.CPCmd.Wait()
-	}
-	if e.TmpDir != "" {
-		os.RemoveAll(e.TmpDir)
-	}
-}
-
-// SetupControlPlaneEnv creates a minimal test environment with only Control Plane
+// SetupControlPlaneEnv creates a minimal test environment with only Control Plane,
+// using the same TestEnv type e2e_suite_test.go's StartTestEnv returns.
 func SetupControlPlaneEnv() (*TestEnv, *http.Client, error) {
 	env := &TestEnv{}
 
@@ -0,0 +1,106 @@
+package e2e_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InfluxDB Line Protocol Ingestion", func() {
+	var (
+		env        *TestEnv
+		client     *http.Client
+		writeURL   string
+		metricsURL string
+	)
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{})
+		client = &http.Client{Timeout: 10 * time.Second}
+		writeURL = "http://127.0.0.1:" + env.MetricsPort + "/write"
+		metricsURL = "http://127.0.0.1:" + env.MetricsPort + "/metrics"
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should ingest a multi-line payload and expose it as apify_ingest metrics", func() {
+		payload := "orders,region=us total=42,revenue=199.5 1700000000000000000\n" +
+			"orders,region=eu total=7,revenue=31.0 1700000000000000000\n"
+		resp, err := client.Post(writeURL, "text/plain", bytes.NewBufferString(payload))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+
+		metricsResp, err := client.Get(metricsURL)
+		Expect(err).NotTo(HaveOccurred())
+		defer metricsResp.Body.Close()
+		data, err := io.ReadAll(metricsResp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		body := string(data)
+
+		Expect(body).To(ContainSubstring("apify_ingest_orders_total"))
+		Expect(body).To(ContainSubstring("apify_ingest_orders_revenue"))
+		Expect(body).To(ContainSubstring(`region="us"`))
+		Expect(body).To(ContainSubstring(`region="eu"`))
+	})
+
+	It("should accept a gzip-compressed line protocol body", func() {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write([]byte("cache_hits,node=a count=1 1700000000000000000\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gz.Close()).To(Succeed())
+
+		req, err := http.NewRequest("POST", writeURL, &buf)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Encoding", "gzip")
+		req.Header.Set("Content-Type", "text/plain")
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+	})
+
+	It("should support the precision query parameter", func() {
+		resp, err := client.Post(writeURL+"?precision=s", "text/plain", bytes.NewBufferString("queue,name=jobs depth=3 1700000000\n"))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+	})
+
+	It("should reject malformed line protocol with the offending line number", func() {
+		payload := "orders,region=us total=42 1700000000000000000\n" +
+			"this is not line protocol\n"
+		resp, err := client.Post(writeURL, "text/plain", bytes.NewBufferString(payload))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+		var errBody map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&errBody)).To(Succeed())
+		Expect(errBody["line"]).To(BeNumerically("==", 2))
+	})
+
+	It("should reject a payload once the per-consumer tag cardinality cap is exceeded", func() {
+		var buf bytes.Buffer
+		for i := 0; i < 2000; i++ {
+			buf.WriteString("spray,id=")
+			buf.WriteString(time.Now().Add(time.Duration(i) * time.Nanosecond).Format("150405.000000000"))
+			buf.WriteString(" value=1 1700000000000000000\n")
+		}
+		resp, err := client.Post(writeURL, "text/plain", &buf)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+	})
+})
@@ -0,0 +1,176 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSON:API Response Format", func() {
+	var (
+		env     *TestEnv
+		client  *http.Client
+		orderID float64
+	)
+
+	jsonAPIGet := func(path string) (int, map[string]interface{}) {
+		req, err := http.NewRequest("GET", env.BaseURL+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		req.Header.Set("Accept", "application/vnd.api+json")
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		var body map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+		return resp.StatusCode, body
+	}
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{
+			"orders": "examples/relations/config/openapi/orders.yaml",
+			"users":  "examples/relations/config/openapi/users.yaml",
+		})
+		client = &http.Client{Timeout: 10 * time.Second}
+
+		createBody := map[string]interface{}{
+			"customerName": "JSON:API Customer",
+			"total":        42.50,
+			"status":       "pending",
+			"items": []map[string]interface{}{
+				{"productName": "Thing", "quantity": 1, "price": 42.50},
+			},
+		}
+		payload, _ := json.Marshal(createBody)
+		req, err := http.NewRequest("POST", env.BaseURL+"/orders", bytes.NewBuffer(payload))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		var created map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&created)).To(Succeed())
+		orderID = created["id"].(float64)
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should return a {data, relationships, links} envelope instead of the flat JSON shape", func() {
+		status, body := jsonAPIGet(fmt.Sprintf("/orders/%d", int(orderID)))
+		Expect(status).To(Equal(http.StatusOK))
+
+		data, ok := body["data"].(map[string]interface{})
+		Expect(ok).To(BeTrue(), "expected a top-level data member")
+		Expect(data["type"]).To(Equal("orders"))
+		Expect(data["id"]).To(Equal(fmt.Sprintf("%d", int(orderID))))
+
+		attrs := data["attributes"].(map[string]interface{})
+		Expect(attrs["customerName"]).To(Equal("JSON:API Customer"))
+		Expect(attrs).NotTo(HaveKey("items"))
+
+		relationships := data["relationships"].(map[string]interface{})
+		Expect(relationships).To(HaveKey("items"))
+
+		Expect(body).To(HaveKey("links"))
+	})
+
+	It("should only eager-load relations named in ?include=, unlike the always-on REST behavior", func() {
+		status, bare := jsonAPIGet(fmt.Sprintf("/orders/%d", int(orderID)))
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(bare).NotTo(HaveKey("included"))
+
+		status, included := jsonAPIGet(fmt.Sprintf("/orders/%d?include=items", int(orderID)))
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(included).To(HaveKey("included"))
+
+		items := included["included"].([]interface{})
+		Expect(items).To(HaveLen(1))
+		Expect(items[0].(map[string]interface{})["type"]).To(Equal("order_items"))
+	})
+
+	It("should honor sparse fieldsets via ?fields[orders]=", func() {
+		status, body := jsonAPIGet(fmt.Sprintf("/orders/%d?fields[orders]=customerName,total", int(orderID)))
+		Expect(status).To(Equal(http.StatusOK))
+
+		data := body["data"].(map[string]interface{})
+		attrs := data["attributes"].(map[string]interface{})
+		Expect(attrs).To(HaveKey("customerName"))
+		Expect(attrs).To(HaveKey("total"))
+		Expect(attrs).NotTo(HaveKey("status"))
+	})
+
+	It("should support ?sort= and ?page[number]= on list endpoints", func() {
+		status, body := jsonAPIGet("/orders?sort=-total&page[number]=1&page[size]=10")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body["data"]).NotTo(BeNil())
+	})
+
+	It("should add hasMany members via the relationships endpoint without replacing the collection", func() {
+		addBody := map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"type": "order_items", "attributes": map[string]interface{}{
+					"productName": "Extra Thing", "quantity": 3, "price": 5.00,
+				}},
+			},
+		}
+		payload, _ := json.Marshal(addBody)
+
+		req, err := http.NewRequest("POST", env.BaseURL+fmt.Sprintf("/orders/%d/relationships/items", int(orderID)), bytes.NewBuffer(payload))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		req.Header.Set("Content-Type", "application/vnd.api+json")
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		status, body := jsonAPIGet(fmt.Sprintf("/orders/%d?include=items", int(orderID)))
+		Expect(status).To(Equal(http.StatusOK))
+		items := body["included"].([]interface{})
+		Expect(items).To(HaveLen(2))
+	})
+
+	It("should remove a single hasMany member via DELETE on the relationships endpoint", func() {
+		status, body := jsonAPIGet(fmt.Sprintf("/orders/%d?include=items", int(orderID)))
+		Expect(status).To(Equal(http.StatusOK))
+		items := body["included"].([]interface{})
+		itemID := items[0].(map[string]interface{})["id"]
+
+		removeBody := map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"type": "order_items", "id": itemID},
+			},
+		}
+		payload, _ := json.Marshal(removeBody)
+
+		req, err := http.NewRequest("DELETE", env.BaseURL+fmt.Sprintf("/orders/%d/relationships/items", int(orderID)), bytes.NewBuffer(payload))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		req.Header.Set("Content-Type", "application/vnd.api+json")
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		status, body = jsonAPIGet(fmt.Sprintf("/orders/%d?include=items", int(orderID)))
+		Expect(status).To(Equal(http.StatusOK))
+		items = body["included"].([]interface{})
+		Expect(items).To(HaveLen(0))
+	})
+})
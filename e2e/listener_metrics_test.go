@@ -0,0 +1,101 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Per-Listener Prometheus Metrics", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{})
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	scrapeMetrics := func() string {
+		resp, err := client.Get("http://127.0.0.1:" + env.MetricsPort + "/metrics")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	It("should label request metrics with listener_name and api_name only for listeners that received traffic", func() {
+		listenerConfig := map[string]interface{}{
+			"name":     "idle-listener",
+			"port":     9401,
+			"ip":       "0.0.0.0",
+			"protocol": "HTTP",
+			"metrics": map[string]interface{}{
+				"buckets": []float64{0.01, 0.05, 0.1, 0.5, 1},
+			},
+		}
+		body, _ := json.Marshal(listenerConfig)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/listeners", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		req, err := http.NewRequest("GET", env.BaseURL+"/items", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		trafficResp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		trafficResp.Body.Close()
+
+		time.Sleep(200 * time.Millisecond)
+		body2 := scrapeMetrics()
+
+		Expect(body2).To(ContainSubstring(`listener_name=`))
+		Expect(body2).NotTo(ContainSubstring(`listener_name="idle-listener"`))
+		Expect(body2).To(ContainSubstring(`api_name=`))
+		Expect(body2).To(ContainSubstring(`path_template=`))
+		Expect(body2).To(ContainSubstring(`status_class="2xx"`))
+		Expect(body2).To(ContainSubstring(`le="0.005"`))
+	})
+
+	It("should expose open_connections and listener_up gauges per listener", func() {
+		body := scrapeMetrics()
+		Expect(body).To(ContainSubstring("apify_open_connections"))
+		Expect(body).To(ContainSubstring("apify_listener_up"))
+		Expect(body).To(ContainSubstring(`listener=`))
+	})
+
+	It("should increment listener_reload_total when a listener's bindings change", func() {
+		apiConfig := map[string]interface{}{
+			"name":    "reload-api",
+			"version": "1.0.0",
+			"spec": map[string]interface{}{
+				"openapi": "3.0.0",
+				"info":    map[string]interface{}{"title": "Reload API", "version": "1.0.0"},
+				"paths":   map[string]interface{}{},
+			},
+		}
+		body, _ := json.Marshal(apiConfig)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/apis", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		Eventually(func() string {
+			return scrapeMetrics()
+		}, "10s", "500ms").Should(ContainSubstring("apify_listener_reload_total{"))
+	})
+})
@@ -0,0 +1,134 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Per-Listener Middleware Chains", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		env, client, err = SetupControlPlaneEnv()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	createMiddleware := func(name, kind string, config map[string]interface{}) string {
+		payload := map[string]interface{}{"name": name, "type": kind, "config": config}
+		body, _ := json.Marshal(payload)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/middlewares", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		var result map[string]interface{}
+		Expect(decodeJSON(resp, &result)).To(Succeed())
+		return result["id"].(string)
+	}
+
+	It("should create, list and delete middleware definitions", func() {
+		id := createMiddleware("strict-rate-limit", "rate-limit", map[string]interface{}{"requests_per_second": 1, "burst": 1})
+
+		resp, err := client.Get(env.CPBaseURL + "/apify/admin/middlewares")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var list []map[string]interface{}
+		Expect(decodeJSON(resp, &list)).To(Succeed())
+		Expect(list).To(HaveLen(1))
+
+		delResp, err := deleteRequest(client, env.CPBaseURL+"/apify/admin/middlewares/"+id)
+		Expect(err).NotTo(HaveOccurred())
+		defer delResp.Body.Close()
+		Expect(delResp.StatusCode).To(Equal(http.StatusNoContent))
+	})
+
+	It("should apply divergent middleware chains to listeners sharing the same API", func() {
+		strictID := createMiddleware("strict-rate-limit", "rate-limit", map[string]interface{}{"requests_per_second": 1, "burst": 1})
+		createMiddleware("lenient-rate-limit", "rate-limit", map[string]interface{}{"requests_per_second": 1000, "burst": 1000})
+
+		strictListener := map[string]interface{}{
+			"name":        "strict-listener",
+			"port":        9201,
+			"ip":          "0.0.0.0",
+			"protocol":    "HTTP",
+			"middlewares": []string{"strict-rate-limit"},
+		}
+		lenientListener := map[string]interface{}{
+			"name":        "lenient-listener",
+			"port":        9202,
+			"ip":          "0.0.0.0",
+			"protocol":    "HTTP",
+			"middlewares": []string{"lenient-rate-limit"},
+		}
+		for _, l := range []map[string]interface{}{strictListener, lenientListener} {
+			body, _ := json.Marshal(l)
+			resp, err := client.Post(env.CPBaseURL+"/apify/admin/listeners", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+		}
+
+		apiConfig := map[string]interface{}{
+			"name":    "shared-api",
+			"version": "1.0.0",
+			"spec": map[string]interface{}{
+				"openapi": "3.0.0",
+				"info":    map[string]interface{}{"title": "Shared API", "version": "1.0.0"},
+				"paths":   map[string]interface{}{},
+			},
+			"listeners": []string{"strict-listener", "lenient-listener"},
+		}
+		body, _ := json.Marshal(apiConfig)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/apis", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		dpClient := &http.Client{Timeout: 5 * time.Second}
+
+		Eventually(func() int {
+			var hit429 int
+			for i := 0; i < 10; i++ {
+				resp, err := dpClient.Get("http://127.0.0.1:9201/items")
+				if err == nil {
+					if resp.StatusCode == http.StatusTooManyRequests {
+						hit429++
+					}
+					resp.Body.Close()
+				}
+			}
+			return hit429
+		}, "10s", "500ms").Should(BeNumerically(">", 0))
+
+		var hit429 int
+		for i := 0; i < 10; i++ {
+			resp, err := dpClient.Get("http://127.0.0.1:9202/items")
+			if err == nil {
+				if resp.StatusCode == http.StatusTooManyRequests {
+					hit429++
+				}
+				resp.Body.Close()
+			}
+		}
+		Expect(hit429).To(Equal(0))
+
+		Expect(strictID).NotTo(BeEmpty())
+	})
+})
@@ -0,0 +1,140 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Graceful Listener Reconfiguration", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		env, client, err = SetupControlPlaneEnv()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	createListener := func(name string, port int) string {
+		listenerConfig := map[string]interface{}{
+			"name":     name,
+			"port":     port,
+			"ip":       "0.0.0.0",
+			"protocol": "HTTP",
+		}
+		body, _ := json.Marshal(listenerConfig)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/listeners", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		var result map[string]interface{}
+		Expect(decodeJSON(resp, &result)).To(Succeed())
+		return result["id"].(string)
+	}
+
+	It("should report reload status for a listener's observable state", func() {
+		id := createListener("status-listener", 9301)
+
+		resp, err := client.Get(env.CPBaseURL + "/apify/admin/listeners/" + id + "/status")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var status map[string]interface{}
+		Expect(decodeJSON(resp, &status)).To(Succeed())
+		Expect(status["state"]).To(BeElementOf("starting", "running", "draining", "stopped"))
+		Expect(status).To(HaveKey("active_connections"))
+		Expect(status).To(HaveKey("last_reload_at"))
+		Expect(status).To(HaveKey("last_reload_error"))
+	})
+
+	It("should swap a listener's handler without dropping a concurrently held request", func() {
+		id := createListener("swap-listener", 9302)
+
+		apiConfig := map[string]interface{}{
+			"name":    "swap-api-v1",
+			"version": "1.0.0",
+			"spec": map[string]interface{}{
+				"openapi": "3.0.0",
+				"info":    map[string]interface{}{"title": "Swap API v1", "version": "1.0.0"},
+				"paths":   map[string]interface{}{},
+			},
+			"listeners": []string{"swap-listener"},
+		}
+		body, _ := json.Marshal(apiConfig)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/apis", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		dpClient := &http.Client{Timeout: 15 * time.Second}
+
+		Eventually(func() int {
+			resp, err := dpClient.Get("http://127.0.0.1:9302/items")
+			if err != nil {
+				return 0
+			}
+			defer resp.Body.Close()
+			return resp.StatusCode
+		}, "10s", "500ms").Should(Equal(http.StatusOK))
+
+		held := make(chan int, 1)
+		go func() {
+			req, _ := http.NewRequest("GET", "http://127.0.0.1:9302/items?slow=true", nil)
+			resp, err := dpClient.Do(req)
+			if err != nil {
+				held <- -1
+				return
+			}
+			defer resp.Body.Close()
+			held <- resp.StatusCode
+		}()
+
+		apiConfigV2 := map[string]interface{}{
+			"name":    "swap-api-v2",
+			"version": "1.0.0",
+			"spec": map[string]interface{}{
+				"openapi": "3.0.0",
+				"info":    map[string]interface{}{"title": "Swap API v2", "version": "1.0.0"},
+				"paths":   map[string]interface{}{},
+			},
+			"listeners": []string{"swap-listener"},
+		}
+		body2, _ := json.Marshal(apiConfigV2)
+		resp2, err := client.Post(env.CPBaseURL+"/apify/admin/apis", "application/json", bytes.NewBuffer(body2))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp2.Body.Close()
+		Expect(resp2.StatusCode).To(Equal(http.StatusCreated))
+
+		Eventually(held, "15s").Should(Receive(BeNumerically(">", 0)))
+
+		resp3, err := dpClient.Get("http://127.0.0.1:9302/items")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp3.Body.Close()
+		Expect(resp3.StatusCode).To(Equal(http.StatusOK))
+
+		statusResp, err := client.Get(env.CPBaseURL + "/apify/admin/listeners/" + id + "/status")
+		Expect(err).NotTo(HaveOccurred())
+		defer statusResp.Body.Close()
+		Expect(statusResp.StatusCode).To(Equal(http.StatusOK))
+
+		var status map[string]interface{}
+		Expect(decodeJSON(statusResp, &status)).To(Succeed())
+		Expect(status["state"]).To(Equal("running"))
+		Expect(status["last_reload_error"]).To(BeNil())
+	})
+})
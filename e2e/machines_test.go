@@ -0,0 +1,222 @@
+package e2e_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// startMTLSControlPlane boots a Control Plane with control-plane.tls configured
+// for mutual TLS, mirroring SetupControlPlaneEnv but adding the TLS knobs this
+// subsystem introduces.
+func startMTLSControlPlane(clientAuth string) (*TestEnv, string, string) {
+	env := &TestEnv{}
+
+	wd, _ := os.Getwd()
+	projectRoot := filepath.Dir(wd)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	cpPort := fmt.Sprintf("%d", l.Addr().(*net.TCPAddr).Port)
+	l.Close()
+	env.CPBaseURL = "https://127.0.0.1:" + cpPort
+
+	env.TmpDir, err = os.MkdirTemp("", "apify-e2e-machines")
+	Expect(err).NotTo(HaveOccurred())
+	env.ConfigFile = filepath.Join(env.TmpDir, "config.yaml")
+	env.DBFile = filepath.Join(env.TmpDir, "test.sqlite")
+
+	f, err := os.Create(env.DBFile)
+	Expect(err).NotTo(HaveOccurred())
+	f.Close()
+
+	caCert := filepath.Join(env.TmpDir, "ca.pem")
+
+	configContent := fmt.Sprintf(`
+control-plane:
+  listen:
+    ip: 127.0.0.1
+    port: %s
+  database:
+    driver: sqlite
+    database: //%s
+  tls:
+    ca_cert: %s
+    client_auth: %s
+    auth_type: both
+log_level: "info"
+`, cpPort, env.DBFile, caCert, clientAuth)
+
+	err = os.WriteFile(env.ConfigFile, []byte(configContent), 0644)
+	Expect(err).NotTo(HaveOccurred())
+
+	env.CPCmd = exec.Command("cargo", "run", "--bin", "apify", "--", "--control-plane", "--config", env.ConfigFile)
+	env.CPCmd.Dir = projectRoot
+	env.CPCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+env.DBFile)
+	env.CPCmd.Stdout = GinkgoWriter
+	env.CPCmd.Stderr = GinkgoWriter
+	Expect(env.CPCmd.Start()).To(Succeed())
+
+	return env, cpPort, caCert
+}
+
+// generateClientCert creates a throwaway ECDSA key/cert pair, simulating the
+// CSR a machine would present to POST /apify/admin/machines.
+func generateClientCert(cn string) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM
+}
+
+var _ = Describe("mTLS Machine Enrollment", func() {
+	var env *TestEnv
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should reject admin calls from an unenrolled client", func() {
+		env, _, _ = startMTLSControlPlane("request")
+
+		insecureClient := &http.Client{
+			Timeout:   5 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		}
+
+		Eventually(func() error {
+			_, err := insecureClient.Get(env.CPBaseURL + "/apify/admin/apis")
+			return err
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		resp, err := insecureClient.Get(env.CPBaseURL + "/apify/admin/apis")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should register a machine, issue a cert, and allow authenticated calls", func() {
+		env, _, _ = startMTLSControlPlane("request")
+
+		insecureClient := &http.Client{
+			Timeout:   5 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		}
+		Eventually(func() error {
+			_, err := insecureClient.Get(env.CPBaseURL + "/apify/admin/apis")
+			return err
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		certPEM, _ := generateClientCert("agent-001")
+		enrollPayload := map[string]interface{}{
+			"name":     "agent-001",
+			"password": "agent-pass",
+			"csr":      string(certPEM),
+		}
+		body, _ := json.Marshal(enrollPayload)
+		resp, err := insecureClient.Post(env.CPBaseURL+"/apify/admin/machines", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		var machine map[string]interface{}
+		Expect(decodeJSON(resp, &machine)).To(Succeed())
+		Expect(machine["id"]).NotTo(BeEmpty())
+
+		// Approve the pending enrollment.
+		approveResp, err := insecureClient.Post(env.CPBaseURL+"/apify/admin/machines/validate", "application/json",
+			bytes.NewBuffer(mustJSON(map[string]interface{}{"id": machine["id"]})))
+		Expect(err).NotTo(HaveOccurred())
+		defer approveResp.Body.Close()
+		Expect(approveResp.StatusCode).To(Equal(http.StatusOK))
+
+		// Login with the machine's password to obtain a bearer token as an
+		// alternative credential to presenting the mTLS cert directly.
+		loginResp, err := insecureClient.Post(env.CPBaseURL+"/apify/admin/auth/login", "application/json",
+			bytes.NewBuffer(mustJSON(map[string]interface{}{"name": "agent-001", "password": "agent-pass"})))
+		Expect(err).NotTo(HaveOccurred())
+		defer loginResp.Body.Close()
+		Expect(loginResp.StatusCode).To(Equal(http.StatusOK))
+
+		var tokenResp map[string]interface{}
+		Expect(decodeJSON(loginResp, &tokenResp)).To(Succeed())
+		Expect(tokenResp["token"]).NotTo(BeEmpty())
+
+		req, _ := http.NewRequest("GET", env.CPBaseURL+"/apify/admin/apis", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenResp["token"].(string))
+		authedResp, err := insecureClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer authedResp.Body.Close()
+		Expect(authedResp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should reject a revoked machine with 403", func() {
+		env, _, _ = startMTLSControlPlane("request")
+
+		insecureClient := &http.Client{
+			Timeout:   5 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		}
+		Eventually(func() error {
+			_, err := insecureClient.Get(env.CPBaseURL + "/apify/admin/apis")
+			return err
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		enrollPayload := map[string]interface{}{"name": "agent-revoked", "password": "agent-pass"}
+		resp, err := insecureClient.Post(env.CPBaseURL+"/apify/admin/machines", "application/json", bytes.NewBuffer(mustJSON(enrollPayload)))
+		Expect(err).NotTo(HaveOccurred())
+		var machine map[string]interface{}
+		Expect(decodeJSON(resp, &machine)).To(Succeed())
+
+		revokeResp, err := deleteRequest(insecureClient, env.CPBaseURL+"/apify/admin/machines/"+machine["id"].(string))
+		Expect(err).NotTo(HaveOccurred())
+		defer revokeResp.Body.Close()
+		Expect(revokeResp.StatusCode).To(Equal(http.StatusNoContent))
+
+		loginResp, err := insecureClient.Post(env.CPBaseURL+"/apify/admin/auth/login", "application/json",
+			bytes.NewBuffer(mustJSON(map[string]interface{}{"name": "agent-revoked", "password": "agent-pass"})))
+		Expect(err).NotTo(HaveOccurred())
+		defer loginResp.Body.Close()
+		Expect(loginResp.StatusCode).To(Equal(http.StatusForbidden))
+	})
+})
+
+func mustJSON(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	Expect(err).NotTo(HaveOccurred())
+	return b
+}
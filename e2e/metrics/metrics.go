@@ -0,0 +1,205 @@
+// Package metrics scrapes the Prometheus text-exposition format and provides
+// Gomega matchers for asserting on individual series, so metrics ports don't
+// just get a bind check but become a first-class tested surface.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// Sample is a single labeled time series value.
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// MetricFamilies indexes every scraped sample by metric name.
+type MetricFamilies map[string][]Sample
+
+// WithLabels selects samples whose label set is a superset of this map.
+type WithLabels map[string]string
+
+// ScrapeMetrics fetches url and parses the Prometheus text-exposition format
+// (including HELP/TYPE comment lines, which are skipped) into MetricFamilies.
+func ScrapeMetrics(url string) (MetricFamilies, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("metrics: unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return parse(resp.Body)
+}
+
+func parse(r io.Reader) (MetricFamilies, error) {
+	families := MetricFamilies{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, labels, value, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		families[name] = append(families[name], Sample{Labels: labels, Value: value})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return families, nil
+}
+
+func parseLine(line string) (name string, labels map[string]string, value float64, err error) {
+	labels = map[string]string{}
+
+	braceIdx := strings.IndexByte(line, '{')
+	var rest string
+	if braceIdx == -1 {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return "", nil, 0, fmt.Errorf("metrics: malformed line %q", line)
+		}
+		name = fields[0]
+		rest = fields[1]
+	} else {
+		name = strings.TrimSpace(line[:braceIdx])
+		closeIdx := strings.LastIndexByte(line, '}')
+		if closeIdx == -1 || closeIdx < braceIdx {
+			return "", nil, 0, fmt.Errorf("metrics: malformed line %q", line)
+		}
+		labelStr := line[braceIdx+1 : closeIdx]
+		for _, pair := range splitLabels(labelStr) {
+			kv := strings.SplitN(pair, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			labels[kv[0]] = strings.Trim(kv[1], `"`)
+		}
+		rest = strings.TrimSpace(line[closeIdx+1:])
+	}
+
+	valueField := strings.Fields(rest)[0]
+	value, err = strconv.ParseFloat(valueField, 64)
+	if err != nil {
+		return "", nil, 0, fmt.Errorf("metrics: malformed value in line %q: %w", line, err)
+	}
+	return name, labels, value, nil
+}
+
+// splitLabels splits a comma-separated label list while respecting commas
+// inside quoted label values.
+func splitLabels(s string) []string {
+	var out []string
+	var cur strings.Builder
+	inQuotes := false
+	for _, r := range s {
+		switch r {
+		case '"':
+			inQuotes = !inQuotes
+			cur.WriteRune(r)
+		case ',':
+			if inQuotes {
+				cur.WriteRune(r)
+			} else {
+				out = append(out, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		out = append(out, cur.String())
+	}
+	return out
+}
+
+// Match returns every sample for this label selector within the family.
+func (f MetricFamilies) Match(name string, selector WithLabels) []Sample {
+	var matches []Sample
+	for _, sample := range f[name] {
+		if sample.matchesLabels(selector) {
+			matches = append(matches, sample)
+		}
+	}
+	return matches
+}
+
+func (s Sample) matchesLabels(selector WithLabels) bool {
+	for k, v := range selector {
+		if s.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// HaveMetric succeeds when families contains at least one sample for name
+// matching selector whose value satisfies valueMatcher, e.g.:
+//
+//	Expect(families).To(HaveMetric("apify_http_requests_total",
+//	    metrics.WithLabels{"code": "200", "route": "/items"}, gomega.BeNumerically(">=", 1)))
+func HaveMetric(name string, selector WithLabels, valueMatcher types.GomegaMatcher) types.GomegaMatcher {
+	return &haveMetricMatcher{name: name, selector: selector, valueMatcher: valueMatcher}
+}
+
+type haveMetricMatcher struct {
+	name         string
+	selector     WithLabels
+	valueMatcher types.GomegaMatcher
+	failures     []string
+}
+
+func (m *haveMetricMatcher) Match(actual interface{}) (bool, error) {
+	families, ok := actual.(MetricFamilies)
+	if !ok {
+		return false, fmt.Errorf("HaveMetric expects a metrics.MetricFamilies, got %T", actual)
+	}
+
+	samples := families.Match(m.name, m.selector)
+	if len(samples) == 0 {
+		return false, nil
+	}
+
+	m.failures = nil
+	for _, sample := range samples {
+		matched, err := m.valueMatcher.Match(sample.Value)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+		m.failures = append(m.failures, fmt.Sprintf("%s%v = %v", m.name, sample.Labels, sample.Value))
+	}
+	return false, nil
+}
+
+func (m *haveMetricMatcher) FailureMessage(actual interface{}) string {
+	if len(m.failures) == 0 {
+		return format.Message(actual, fmt.Sprintf("to have a sample for metric %q matching labels %v", m.name, m.selector))
+	}
+	return fmt.Sprintf("expected one of the matching series for %q to satisfy the value matcher, but none did:\n%s",
+		m.name, strings.Join(m.failures, "\n"))
+}
+
+func (m *haveMetricMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(actual, fmt.Sprintf("not to have a sample for metric %q matching labels %v", m.name, m.selector))
+}
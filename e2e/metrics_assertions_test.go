@@ -0,0 +1,90 @@
+package e2e_test
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/apifyhost/apify/e2e/metrics"
+)
+
+var _ = Describe("Prometheus Metrics Assertions", func() {
+	var (
+		env        *TestEnv
+		client     *http.Client
+		metricsURL string
+	)
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{})
+		client = &http.Client{Timeout: 10 * time.Second}
+		metricsURL = "http://127.0.0.1:" + env.MetricsPort + "/metrics"
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should increment the request counter per route/method/status", func() {
+		req, _ := http.NewRequest("GET", env.BaseURL+"/items", nil)
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		time.Sleep(200 * time.Millisecond)
+		families, err := metrics.ScrapeMetrics(metricsURL)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(families).To(metrics.HaveMetric("apify_http_requests_total",
+			metrics.WithLabels{"method": "GET", "route": "/items", "code": "200"},
+			BeNumerically(">=", 1)))
+	})
+
+	It("should record auth failures on a distinct series from successful requests", func() {
+		req, _ := http.NewRequest("GET", env.BaseURL+"/items", nil)
+		req.Header.Set("X-Api-Key", "not-a-real-key")
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+		time.Sleep(200 * time.Millisecond)
+		families, err := metrics.ScrapeMetrics(metricsURL)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(families).To(metrics.HaveMetric("apify_auth_failures_total",
+			metrics.WithLabels{"route": "/items"}, BeNumerically(">=", 1)))
+		Expect(families).To(metrics.HaveMetric("apify_http_requests_total",
+			metrics.WithLabels{"method": "GET", "route": "/items", "code": "401"},
+			BeNumerically(">=", 1)))
+	})
+
+	It("should populate duration histogram buckets after a CRUD flow", func() {
+		createReq, _ := http.NewRequest("POST", env.BaseURL+"/items",
+			strings.NewReader(`{"name":"metrics-item","description":"x","price":1}`))
+		createReq.Header.Set("X-Api-Key", env.APIKey)
+		createReq.Header.Set("Content-Type", "application/json")
+		createResp, err := client.Do(createReq)
+		Expect(err).NotTo(HaveOccurred())
+		createResp.Body.Close()
+
+		listReq, _ := http.NewRequest("GET", env.BaseURL+"/items", nil)
+		listReq.Header.Set("X-Api-Key", env.APIKey)
+		listResp, err := client.Do(listReq)
+		Expect(err).NotTo(HaveOccurred())
+		listResp.Body.Close()
+
+		time.Sleep(200 * time.Millisecond)
+		families, err := metrics.ScrapeMetrics(metricsURL)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(families).To(metrics.HaveMetric("apify_http_request_duration_seconds_bucket",
+			metrics.WithLabels{"route": "/items", "le": "+Inf"}, BeNumerically(">=", 2)))
+	})
+})
@@ -0,0 +1,114 @@
+package e2e_test
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quantile-Aware Request Metrics", func() {
+	var (
+		env        *TestEnv
+		client     *http.Client
+		metricsURL string
+	)
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{})
+		client = &http.Client{Timeout: 10 * time.Second}
+		metricsURL = "http://127.0.0.1:" + env.MetricsPort + "/metrics"
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	scrapeMetrics := func() string {
+		resp, err := client.Get(metricsURL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	It("should expose sub-millisecond histogram buckets with route_template and consumer labels", func() {
+		req, _ := http.NewRequest("GET", env.BaseURL+"/items", nil)
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		time.Sleep(200 * time.Millisecond)
+		body := scrapeMetrics()
+
+		Expect(body).To(ContainSubstring("apify_http_request_duration_seconds_bucket"))
+		Expect(body).To(ContainSubstring(`le="0.0005"`))
+		Expect(body).To(ContainSubstring(`le="0.001"`))
+		Expect(body).To(ContainSubstring(`le="0.0025"`))
+		Expect(body).To(ContainSubstring(`route_template=`))
+		Expect(body).To(ContainSubstring(`consumer=`))
+		Expect(body).To(ContainSubstring(`status_class="2xx"`))
+	})
+
+	It("should emit apify_datasource_query_seconds around SQL execution", func() {
+		req, _ := http.NewRequest("GET", env.BaseURL+"/items", nil)
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		time.Sleep(200 * time.Millisecond)
+		body := scrapeMetrics()
+		Expect(body).To(ContainSubstring("apify_datasource_query_seconds"))
+	})
+
+	It("should fold overflow route_template cardinality into __overflow__", func() {
+		// Hit a long tail of distinct, non-templated paths to exceed the
+		// per-listener route_template cap.
+		for i := 0; i < 50; i++ {
+			req, _ := http.NewRequest("GET", env.BaseURL+"/items/nonexistent-path-"+string(rune('a'+i%26)), nil)
+			req.Header.Set("X-Api-Key", env.APIKey)
+			resp, err := client.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}
+
+		time.Sleep(200 * time.Millisecond)
+		body := scrapeMetrics()
+		Expect(body).To(ContainSubstring(`route_template="__overflow__"`))
+	})
+
+	It("should emit OpenMetrics exemplars carrying a trace id on histogram buckets", func() {
+		req, _ := http.NewRequest("GET", env.BaseURL+"/items", nil)
+		req.Header.Set("X-Api-Key", env.APIKey)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		time.Sleep(200 * time.Millisecond)
+
+		metricsReq, err := http.NewRequest("GET", metricsURL, nil)
+		Expect(err).NotTo(HaveOccurred())
+		metricsReq.Header.Set("Accept", "application/openmetrics-text")
+		metricsResp, err := client.Do(metricsReq)
+		Expect(err).NotTo(HaveOccurred())
+		defer metricsResp.Body.Close()
+		Expect(metricsResp.Header.Get("Content-Type")).To(ContainSubstring("application/openmetrics-text"))
+
+		data, err := io.ReadAll(metricsResp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		body := string(data)
+
+		Expect(body).To(ContainSubstring("apify_http_request_duration_seconds_bucket"))
+		Expect(body).To(ContainSubstring(`# {trace_id="4bf92f3577b34da6a3ce929d0e0e4736"`))
+		Expect(body).To(ContainSubstring("# EOF"))
+	})
+})
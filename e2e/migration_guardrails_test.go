@@ -0,0 +1,331 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Breaking-Change Detection in /_meta/apis", func() {
+	var (
+		env     *TestEnv
+		client  *http.Client
+		v1Spec  string
+		appName string
+	)
+
+	// submitSpec mirrors the helper in migration_test.go, but returns the
+	// response instead of asserting a 200/201 so destructive-migration
+	// cases can inspect the rejection body.
+	submitSpec := func(query, content string) *http.Response {
+		payload := map[string]string{
+			"name":    appName,
+			"version": "1.0.0",
+			"spec":    content,
+		}
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest("POST", env.CPBaseURL+"/_meta/apis"+query, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	BeforeEach(func() {
+		appName = "products-api"
+		v1Spec = `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      summary: Create product
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '200':
+          description: Created
+    get:
+      summary: List products
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        sku:
+          type: string
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+          - name: sku
+            columnType: text
+            nullable: true
+`
+		env = StartTestEnv(map[string]string{"products": "api:" + appName})
+		client = &http.Client{Timeout: 10 * time.Second}
+
+		resp := submitSpec("", v1Spec)
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+		resp.Body.Close()
+		time.Sleep(2 * time.Second)
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	additiveV2 := `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      summary: Create product
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '200':
+          description: Created
+    get:
+      summary: List products
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        sku:
+          type: string
+        price:
+          type: number
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+          - name: sku
+            columnType: text
+            nullable: true
+          - name: price
+            columnType: real
+            nullable: true
+`
+
+	destructiveV2 := `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      summary: Create product
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '200':
+          description: Created
+    get:
+      summary: List products
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+`
+
+	notNullNoDefaultV2 := `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      summary: Create product
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '200':
+          description: Created
+    get:
+      summary: List products
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        sku:
+          type: string
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+          - name: sku
+            columnType: text
+            nullable: false
+            x-migration:
+              backfill: "UNKNOWN"
+`
+
+	It("should accept an additive migration (new nullable column) without a flag", func() {
+		resp := submitSpec("", additiveV2)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+	})
+
+	It("should reject a destructive migration (column drop) with 409 and a diff list", func() {
+		resp := submitSpec("", destructiveV2)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+
+		var body map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+		diffs := body["diffs"].([]interface{})
+		Expect(diffs).NotTo(BeEmpty())
+
+		d := diffs[0].(map[string]interface{})
+		Expect(d).To(HaveKey("path"))
+		Expect(d).To(HaveKey("reason"))
+	})
+
+	It("should accept a forced destructive migration with ?allow_destructive=true", func() {
+		resp := submitSpec("?allow_destructive=true", destructiveV2)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+	})
+
+	It("should accept a NOT NULL addition that supplies an x-migration backfill default", func() {
+		resp := submitSpec("", notNullNoDefaultV2)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+	})
+
+	It("should return the planned DDL without executing it in dry-run mode", func() {
+		resp := submitSpec("?dry_run=true", additiveV2)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var plan map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&plan)).To(Succeed())
+		Expect(plan).To(HaveKey("ddl"))
+
+		req, _ := http.NewRequest("GET", env.BaseURL+"/products", nil)
+		req.Header.Set("X-Api-Key", env.APIKey)
+		listResp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer listResp.Body.Close()
+
+		var products []map[string]interface{}
+		Expect(json.NewDecoder(listResp.Body).Decode(&products)).To(Succeed())
+		for _, p := range products {
+			Expect(p).NotTo(HaveKey("price"))
+		}
+	})
+})
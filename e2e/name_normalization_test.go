@@ -0,0 +1,87 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Case-Insensitive Name Normalization", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		env, client, err = SetupControlPlaneEnv()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should reject a listener name that only differs in case from an existing one", func() {
+		first := map[string]interface{}{"name": "Listener-1", "port": 9101, "ip": "0.0.0.0", "protocol": "HTTP"}
+		body, _ := json.Marshal(first)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/listeners", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		second := map[string]interface{}{"name": "listener-1", "port": 9102, "ip": "0.0.0.0", "protocol": "HTTP"}
+		body2, _ := json.Marshal(second)
+		resp2, err := client.Post(env.CPBaseURL+"/apify/admin/listeners", "application/json", bytes.NewBuffer(body2))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp2.Body.Close()
+		Expect(resp2.StatusCode).To(Equal(http.StatusConflict))
+
+		var conflict map[string]interface{}
+		Expect(decodeJSON(resp2, &conflict)).To(Succeed())
+		Expect(conflict["conflicts_with"]).To(Equal("listener-1"))
+	})
+
+	It("should look up a resource name case-insensitively", func() {
+		config := map[string]interface{}{"name": "Test-DB", "config": map[string]interface{}{"driver": "sqlite", "database": ":memory:"}}
+		body, _ := json.Marshal(config)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/datasources", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		resp2, err := client.Get(env.CPBaseURL + "/apify/admin/datasources/test-db")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp2.Body.Close()
+		Expect(resp2.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should resolve mixed-case listener references when importing an API", func() {
+		listenerConfig := map[string]interface{}{"name": "Listener-1", "port": 9103, "ip": "0.0.0.0", "protocol": "HTTP"}
+		body, _ := json.Marshal(listenerConfig)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/listeners", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		apiConfig := map[string]interface{}{
+			"name":    "mixed-case-api",
+			"version": "1.0.0",
+			"spec": map[string]interface{}{
+				"openapi": "3.0.0",
+				"info":    map[string]interface{}{"title": "Mixed Case", "version": "1.0.0"},
+				"paths":   map[string]interface{}{},
+			},
+			"listeners": []string{"Listener-1"},
+		}
+		body2, _ := json.Marshal(apiConfig)
+		resp2, err := client.Post(env.CPBaseURL+"/apify/admin/apis", "application/json", bytes.NewBuffer(body2))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp2.Body.Close()
+		Expect(resp2.StatusCode).To(Equal(http.StatusCreated))
+	})
+})
@@ -0,0 +1,300 @@
+package e2e_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// mockOIDCProvider is a lightweight in-process issuer that publishes
+// /.well-known/openid-configuration and a JWKS, and signs JWTs with RS256
+// so auth configs can be exercised functionally instead of only as CRUD rows.
+type mockOIDCProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+	issuer string
+}
+
+func startMockOIDCProvider() *mockOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+
+	p := &mockOIDCProvider{key: key, kid: "e2e-oidc-key-1"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":   p.issuer,
+			"jwks_uri": p.issuer + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		n := base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes())
+		e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes())
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]interface{}{
+				{"kty": "RSA", "use": "sig", "alg": "RS256", "kid": p.kid, "n": n, "e": e},
+			},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		Expect(r.ParseForm()).To(Succeed())
+
+		var sub string
+		switch r.FormValue("grant_type") {
+		case "authorization_code":
+			if r.FormValue("code") != "mock-auth-code" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			sub = "e2e-callback-user"
+		case "refresh_token":
+			if r.FormValue("refresh_token") != "mock-refresh-token" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			sub = "e2e-user"
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		accessToken := p.issueToken(map[string]interface{}{
+			"iss": p.issuer,
+			"aud": "apify-e2e",
+			"sub": sub,
+			"exp": time.Now().Add(time.Hour).Unix(),
+			"nbf": time.Now().Add(-time.Minute).Unix(),
+			"iat": time.Now().Add(-time.Minute).Unix(),
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  accessToken,
+			"refresh_token": "mock-refresh-token",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+		})
+	})
+
+	p.server = httptest.NewServer(mux)
+	p.issuer = p.server.URL
+	return p
+}
+
+func (p *mockOIDCProvider) Close() { p.server.Close() }
+
+// issueToken signs a JWT with the provider's RSA key, letting tests control
+// exp/nbf/aud/iss to exercise each rejection path.
+func (p *mockOIDCProvider) issueToken(claims map[string]interface{}) string {
+	header := map[string]interface{}{"alg": "RS256", "typ": "JWT", "kid": p.kid}
+	headerJSON, _ := json.Marshal(header)
+	claimsJSON, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	hashed := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, hashed[:])
+	Expect(err).NotTo(HaveOccurred())
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// startOIDCControlPlane boots a Control Plane with an oidc auth config
+// pointed at the given mock provider.
+func startOIDCControlPlane(provider *mockOIDCProvider) *TestEnv {
+	env := &TestEnv{}
+
+	wd, _ := os.Getwd()
+	projectRoot := filepath.Dir(wd)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	cpPort := fmt.Sprintf("%d", l.Addr().(*net.TCPAddr).Port)
+	l.Close()
+	env.CPBaseURL = "http://127.0.0.1:" + cpPort
+
+	tmpDir, err := os.MkdirTemp("", "apify-e2e-oidc")
+	Expect(err).NotTo(HaveOccurred())
+	env.TmpDir = tmpDir
+	env.ConfigFile = filepath.Join(tmpDir, "config.yaml")
+	env.DBFile = filepath.Join(tmpDir, "test.sqlite")
+
+	f, err := os.Create(env.DBFile)
+	Expect(err).NotTo(HaveOccurred())
+	f.Close()
+
+	configContent := fmt.Sprintf(`
+control-plane:
+  listen:
+    ip: 127.0.0.1
+    port: %s
+  database:
+    driver: sqlite
+    database: //%s
+
+auth:
+  - name: mock-oidc
+    type: oidc
+    enabled: true
+    config:
+      issuer_url: %s
+      audiences: ["apify-e2e"]
+      client_id: apify-e2e
+      client_secret: apify-e2e-secret
+      redirect_uri: %s/apify/admin/auth/mock-oidc/callback
+
+log_level: "info"
+`, cpPort, env.DBFile, provider.issuer, env.CPBaseURL)
+
+	Expect(os.WriteFile(env.ConfigFile, []byte(configContent), 0644)).To(Succeed())
+
+	env.CPCmd = exec.Command("cargo", "run", "--bin", "apify", "--", "--control-plane", "--config", env.ConfigFile)
+	env.CPCmd.Dir = projectRoot
+	env.CPCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+env.DBFile)
+	env.CPCmd.Stdout = GinkgoWriter
+	env.CPCmd.Stderr = GinkgoWriter
+	Expect(env.CPCmd.Start()).To(Succeed())
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	Eventually(func() error {
+		resp, err := client.Get(env.CPBaseURL + "/_meta/apis")
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("status code %d", resp.StatusCode)
+		}
+		return nil
+	}, 60*time.Second, 1*time.Second).Should(Succeed())
+
+	return env
+}
+
+var _ = Describe("OIDC Functional Authentication", func() {
+	var (
+		provider *mockOIDCProvider
+		env      *TestEnv
+		client   *http.Client
+	)
+
+	BeforeEach(func() {
+		provider = startMockOIDCProvider()
+		env = startOIDCControlPlane(provider)
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+		if provider != nil {
+			provider.Close()
+		}
+	})
+
+	validClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss": provider.issuer,
+			"aud": "apify-e2e",
+			"sub": "e2e-user",
+			"exp": time.Now().Add(time.Hour).Unix(),
+			"nbf": time.Now().Add(-time.Minute).Unix(),
+			"iat": time.Now().Add(-time.Minute).Unix(),
+		}
+	}
+
+	authedGet := func(token string) *http.Response {
+		req, err := http.NewRequest("GET", env.CPBaseURL+"/apify/admin/apis", nil)
+		Expect(err).NotTo(HaveOccurred())
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	It("should reject a request with no token", func() {
+		resp := authedGet("")
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should reject an expired token", func() {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		resp := authedGet(provider.issueToken(claims))
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should reject a token with the wrong audience", func() {
+		claims := validClaims()
+		claims["aud"] = "some-other-service"
+		resp := authedGet(provider.issueToken(claims))
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should reject an unsigned token", func() {
+		header := map[string]interface{}{"alg": "none", "typ": "JWT"}
+		headerJSON, _ := json.Marshal(header)
+		claimsJSON, _ := json.Marshal(validClaims())
+		unsigned := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON) + "."
+		resp := authedGet(unsigned)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should accept a valid bearer token", func() {
+		resp := authedGet(provider.issueToken(validClaims()))
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should exchange a refresh token for a new access token and use it", func() {
+		tokenResp, err := client.PostForm(provider.issuer+"/token", map[string][]string{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {"mock-refresh-token"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer tokenResp.Body.Close()
+		Expect(tokenResp.StatusCode).To(Equal(http.StatusOK))
+
+		var refreshed TokenResponse
+		Expect(json.NewDecoder(tokenResp.Body).Decode(&refreshed)).To(Succeed())
+		Expect(refreshed.AccessToken).NotTo(BeEmpty())
+
+		resp := authedGet(refreshed.AccessToken)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should complete the redirect_uri authorization code callback flow", func() {
+		callbackURL := fmt.Sprintf("%s/apify/admin/auth/mock-oidc/callback?code=mock-auth-code&state=e2e-state", env.CPBaseURL)
+		req, err := http.NewRequest("GET", callbackURL, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(SatisfyAny(Equal(http.StatusOK), Equal(http.StatusFound)))
+	})
+})
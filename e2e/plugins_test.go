@@ -0,0 +1,218 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API Plugin Pipeline", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		env, client, err = SetupControlPlaneEnv()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	Describe("Global Plugin CRUD", func() {
+		It("should create, list, and delete a global plugin definition", func() {
+			baseURL := env.CPBaseURL + "/apify/admin/plugins"
+
+			pluginConfig := map[string]interface{}{
+				"name": "rate-limit",
+				"config": map[string]interface{}{
+					"requests_per_second": 5,
+					"burst":               10,
+				},
+			}
+			body, _ := json.Marshal(pluginConfig)
+			resp, err := client.Post(baseURL, "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+			var created map[string]interface{}
+			Expect(decodeJSON(resp, &created)).To(Succeed())
+			pluginID := created["id"].(string)
+
+			resp, err = client.Get(baseURL)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			var plugins []map[string]interface{}
+			Expect(decodeJSON(resp, &plugins)).To(Succeed())
+			Expect(len(plugins)).To(BeNumerically(">", 0))
+
+			resp, err = deleteRequest(client, baseURL+"/"+pluginID)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+		})
+	})
+
+	Describe("Attaching plugins to an API", func() {
+		It("should persist a plugins array on the API config", func() {
+			baseURL := env.CPBaseURL + "/apify/admin/apis"
+
+			apiConfig := map[string]interface{}{
+				"name":    "plugin-api",
+				"version": "1.0.0",
+				"spec": map[string]interface{}{
+					"openapi": "3.0.0",
+					"info":    map[string]interface{}{"title": "Plugin API", "version": "1.0.0"},
+					"paths": map[string]interface{}{
+						"/items": map[string]interface{}{
+							"get": map[string]interface{}{
+								"responses": map[string]interface{}{
+									"200": map[string]interface{}{"description": "OK"},
+								},
+							},
+						},
+					},
+				},
+				"plugins": []map[string]interface{}{
+					{"name": "rate-limit", "config": map[string]interface{}{"requests_per_second": 1, "burst": 1}},
+					{"name": "cors", "config": map[string]interface{}{"allow_origins": []string{"*"}}},
+				},
+			}
+			body, _ := json.Marshal(apiConfig)
+			resp, err := client.Post(baseURL, "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+			var created map[string]interface{}
+			Expect(decodeJSON(resp, &created)).To(Succeed())
+			apiID := created["id"].(string)
+
+			resp, err = client.Get(baseURL + "/" + apiID)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			var api map[string]interface{}
+			Expect(decodeJSON(resp, &api)).To(Succeed())
+			plugins, ok := api["plugins"].([]interface{})
+			Expect(ok).To(BeTrue())
+			Expect(plugins).To(HaveLen(2))
+		})
+
+		It("should attach and detach plugins via PUT /apify/admin/apis/{id}/plugins", func() {
+			baseURL := env.CPBaseURL + "/apify/admin/apis"
+
+			apiConfig := map[string]interface{}{
+				"name":    "plugin-attach-api",
+				"version": "1.0.0",
+				"spec": map[string]interface{}{
+					"openapi": "3.0.0",
+					"info":    map[string]interface{}{"title": "Plugin Attach API", "version": "1.0.0"},
+					"paths":   map[string]interface{}{},
+				},
+			}
+			body, _ := json.Marshal(apiConfig)
+			resp, err := client.Post(baseURL, "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			var created map[string]interface{}
+			Expect(decodeJSON(resp, &created)).To(Succeed())
+			apiID := created["id"].(string)
+
+			attachPayload := []map[string]interface{}{
+				{"name": "jwt-auth", "config": map[string]interface{}{}},
+			}
+			resp, err = putJSON(client, baseURL+"/"+apiID+"/plugins", attachPayload)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			// Detach by sending an empty list.
+			resp, err = putJSON(client, baseURL+"/"+apiID+"/plugins", []map[string]interface{}{})
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+	})
+
+	Describe("Rate-limit plugin enforcement at the data plane", func() {
+		It("should return 429 once the token bucket is exhausted", func() {
+			dpEnv := StartTestEnv(map[string]string{})
+			defer dpEnv.Stop()
+
+			apiConfig := map[string]interface{}{
+				"name":    "rate-limited-api",
+				"version": "1.0.0",
+				"spec": map[string]interface{}{
+					"openapi": "3.0.0",
+					"info":    map[string]interface{}{"title": "Rate Limited", "version": "1.0.0"},
+					"paths": map[string]interface{}{
+						"/limited": map[string]interface{}{
+							"get": map[string]interface{}{
+								"responses": map[string]interface{}{"200": map[string]interface{}{"description": "OK"}},
+							},
+						},
+					},
+				},
+				"plugins": []map[string]interface{}{
+					{"name": "rate-limit", "config": map[string]interface{}{"requests_per_second": 1, "burst": 1}},
+				},
+			}
+			body, _ := json.Marshal(apiConfig)
+			resp, err := client.Post(dpEnv.CPBaseURL+"/apify/admin/apis", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			// Admin-created APIs still need to be attached to the data
+			// plane's listener before anything routes to them, the same
+			// step StartTestEnv performs for its own specFiles via
+			// /_meta/listeners.
+			dpURL, err := url.Parse(dpEnv.BaseURL)
+			Expect(err).NotTo(HaveOccurred())
+			dpPort, err := strconv.Atoi(dpURL.Port())
+			Expect(err).NotTo(HaveOccurred())
+			listenerPayload, _ := json.Marshal(map[string]interface{}{
+				"port":     dpPort,
+				"ip":       "127.0.0.1",
+				"protocol": "HTTP",
+				"apis":     []string{"rate-limited-api"},
+			})
+			listenerResp, err := client.Post(dpEnv.CPBaseURL+"/_meta/listeners", "application/json", bytes.NewBuffer(listenerPayload))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listenerResp.StatusCode).To(Equal(http.StatusCreated))
+			listenerResp.Body.Close()
+
+			dpClient := &http.Client{Timeout: 5 * time.Second}
+			var lastStatus int
+			Eventually(func() int {
+				req, _ := http.NewRequest("GET", dpEnv.BaseURL+"/limited", nil)
+				req.Header.Set("X-Api-Key", dpEnv.APIKey)
+				r, err := dpClient.Do(req)
+				if err != nil {
+					return 0
+				}
+				defer r.Body.Close()
+				lastStatus = r.StatusCode
+				return lastStatus
+			}, "10s", "200ms").Should(Or(Equal(http.StatusOK), Equal(http.StatusTooManyRequests)))
+
+			req, _ := http.NewRequest("GET", dpEnv.BaseURL+"/limited", nil)
+			req.Header.Set("X-Api-Key", dpEnv.APIKey)
+			resp2, err := dpClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp2.Body.Close()
+			Expect(resp2.StatusCode).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+})
@@ -0,0 +1,155 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("readOnly/writeOnly Enforcement at the Request/Response Boundary", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	spec := `
+openapi: 3.0.0
+info:
+  title: Accounts API
+  version: 1.0.0
+paths:
+  /accounts:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Account'
+      responses:
+        '200':
+          description: Created
+    get:
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Account'
+  /accounts-lenient:
+    post:
+      x-strict-io: false
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Account'
+      responses:
+        '200':
+          description: Created
+components:
+  schemas:
+    Account:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        password:
+          type: string
+          writeOnly: true
+      x-table-schema:
+        tableName: accounts
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+          - name: password
+            columnType: text
+            nullable: true
+`
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{"accounts": "api:accounts-api"})
+		client = &http.Client{Timeout: 10 * time.Second}
+
+		payload := map[string]string{"name": "accounts-api", "version": "1.0.0", "spec": spec}
+		body, _ := json.Marshal(payload)
+		resp, err := client.Post(env.CPBaseURL+"/_meta/apis", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+		resp.Body.Close()
+		time.Sleep(2 * time.Second)
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should reject a POST body that sets the readOnly id field with 400 and a property pointer", func() {
+		body, _ := json.Marshal(map[string]interface{}{"name": "x", "id": 5})
+		req, _ := http.NewRequest("POST", env.BaseURL+"/accounts", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Api-Key", env.APIKey)
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+		var problem map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&problem)).To(Succeed())
+		Expect(problem["detail"]).To(ContainSubstring("id"))
+	})
+
+	It("should never include the writeOnly password field in GET/list responses", func() {
+		body, _ := json.Marshal(map[string]interface{}{"name": "secure-user", "password": "hunter2"})
+		req, _ := http.NewRequest("POST", env.BaseURL+"/accounts", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Api-Key", env.APIKey)
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		resp.Body.Close()
+
+		req, _ = http.NewRequest("GET", env.BaseURL+"/accounts", nil)
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var accounts []map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&accounts)).To(Succeed())
+		for _, a := range accounts {
+			Expect(a).NotTo(HaveKey("password"))
+		}
+	})
+
+	It("should allow a readOnly field in the request body when x-strict-io: false on that operation", func() {
+		body, _ := json.Marshal(map[string]interface{}{"name": "lenient-user", "id": 999})
+		req, _ := http.NewRequest("POST", env.BaseURL+"/accounts-lenient", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Api-Key", env.APIKey)
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+})
@@ -0,0 +1,221 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const remoteProductsV1 = `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '200':
+          description: Created
+    get:
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+`
+
+const remoteProductsV2 = `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '200':
+          description: Created
+    get:
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        price:
+          type: number
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+          - name: price
+            columnType: real
+            nullable: true
+`
+
+var _ = Describe("Remote Spec Sources", func() {
+	var (
+		env        *TestEnv
+		client     *http.Client
+		specServer *httptest.Server
+		served     atomic.Value // string
+	)
+
+	BeforeEach(func() {
+		served.Store(remoteProductsV1)
+		specServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/yaml")
+			w.Write([]byte(served.Load().(string)))
+		}))
+
+		// As in migration_test.go, the logical name is pre-declared on the
+		// listener; the actual spec arrives afterwards through the meta
+		// plane instead of being read from a local file.
+		env = StartTestEnv(map[string]string{"products": "api:products-api"})
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+		if specServer != nil {
+			specServer.Close()
+		}
+	})
+
+	registerRemote := func() *http.Response {
+		payload := map[string]string{
+			"name":      "products-api",
+			"version":   "1.0.0",
+			"sourceURL": specServer.URL,
+		}
+		body, _ := json.Marshal(payload)
+		resp, err := client.Post(env.CPBaseURL+"/_meta/apis", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	It("should fetch and register a spec from a remote URL instead of an inline body", func() {
+		resp := registerRemote()
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Or(Equal(http.StatusOK), Equal(http.StatusCreated)))
+	})
+
+	It("should migrate a running instance when the remote spec flips from v1 to v2, without a restart", func() {
+		resp := registerRemote()
+		Expect(resp.StatusCode).To(Or(Equal(http.StatusOK), Equal(http.StatusCreated)))
+		resp.Body.Close()
+
+		time.Sleep(2 * time.Second)
+
+		req, _ := http.NewRequest("POST", env.BaseURL+"/products", bytes.NewBufferString(`{"name":"Laptop"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Api-Key", env.APIKey)
+		createResp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer createResp.Body.Close()
+		Expect(createResp.StatusCode).To(Equal(http.StatusOK))
+
+		served.Store(remoteProductsV2)
+
+		Eventually(func() int {
+			req, _ := http.NewRequest("POST", env.BaseURL+"/products", bytes.NewBufferString(`{"name":"Mouse","price":29.99}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Api-Key", env.APIKey)
+			resp, err := client.Do(req)
+			if err != nil {
+				return 0
+			}
+			defer resp.Body.Close()
+			return resp.StatusCode
+		}, "20s", "1s").Should(Equal(http.StatusOK))
+	})
+
+	It("should retry with exponential backoff when the remote source returns 5xx", func() {
+		var requestCount int64
+		flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt64(&requestCount, 1)
+			if n < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/yaml")
+			w.Write([]byte(remoteProductsV1))
+		}))
+		defer flaky.Close()
+
+		payload := map[string]string{
+			"name":      "flaky-api",
+			"version":   "1.0.0",
+			"sourceURL": flaky.URL,
+		}
+		body, _ := json.Marshal(payload)
+		resp, err := client.Post(env.CPBaseURL+"/_meta/apis", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Or(Equal(http.StatusOK), Equal(http.StatusCreated), Equal(http.StatusAccepted)))
+
+		Eventually(func() int64 { return atomic.LoadInt64(&requestCount) }, "15s", "500ms").Should(BeNumerically(">=", 3))
+	})
+})
@@ -0,0 +1,107 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Request ID Propagation and Error Body Shape", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{
+			"items": "examples/basic/config/openapi/items.yaml",
+		})
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should return a problem+json body carrying the same request id as the X-Request-Id header", func() {
+		req, err := http.NewRequest("GET", env.BaseURL+"/items", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", "not-a-real-key")
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+		requestID := resp.Header.Get("X-Request-Id")
+		Expect(requestID).NotTo(BeEmpty())
+
+		var problem map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&problem)).To(Succeed())
+		Expect(problem).To(HaveKey("type"))
+		Expect(problem).To(HaveKey("title"))
+		Expect(problem).To(HaveKey("status"))
+		Expect(problem).To(HaveKey("detail"))
+		Expect(problem).To(HaveKey("instance"))
+		Expect(problem["request_id"]).To(Equal(requestID))
+	})
+
+	It("should correlate the response's X-Request-Id with a line in the data plane's access log", func() {
+		req, err := http.NewRequest("GET", env.BaseURL+"/items/does-not-exist", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+
+		requestID := resp.Header.Get("X-Request-Id")
+		Expect(requestID).NotTo(BeEmpty())
+
+		Eventually(func() string {
+			return env.LogBuffer.String()
+		}, "5s", "200ms").Should(ContainSubstring(requestID))
+	})
+
+	It("should assign a distinct request id to every request", func() {
+		ids := map[string]bool{}
+		for i := 0; i < 3; i++ {
+			req, err := http.NewRequest("GET", env.BaseURL+"/items", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("X-Api-Key", env.APIKey)
+
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			id := resp.Header.Get("X-Request-Id")
+			Expect(id).NotTo(BeEmpty())
+			Expect(ids).NotTo(HaveKey(id))
+			ids[id] = true
+		}
+	})
+
+	It("should reject a malformed create request with a 400 problem+json body", func() {
+		req, err := http.NewRequest("POST", env.BaseURL+"/items", strings.NewReader(`{not valid json`))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(resp.Header.Get("Content-Type")).To(ContainSubstring("application/problem+json"))
+
+		var problem map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&problem)).To(Succeed())
+		Expect(problem["status"]).To(BeNumerically("==", http.StatusBadRequest))
+	})
+})
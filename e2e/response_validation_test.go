@@ -0,0 +1,255 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OpenAPI Response Validation", func() {
+	var (
+		cpCmd       *exec.Cmd
+		serverCmd   *exec.Cmd
+		serverPort  string
+		metricsPort string
+		baseURL     string
+		configFile  string
+		dbFile      string
+		client      *http.Client
+		tmpDir      string
+	)
+
+	startWithMode := func(mode string, sampleRate float64) {
+		var err error
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		serverPort = fmt.Sprintf("%d", l.Addr().(*net.TCPAddr).Port)
+		l.Close()
+		baseURL = "http://127.0.0.1:" + serverPort
+
+		ml, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		metricsPort = fmt.Sprintf("%d", ml.Addr().(*net.TCPAddr).Port)
+		ml.Close()
+
+		tmpDir, err = os.MkdirTemp("", "apify-response-validation-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		configFile = filepath.Join(tmpDir, "config.yaml")
+		dbFile = filepath.Join(tmpDir, "test.sqlite")
+
+		apiSpecJSON := `
+{
+  "openapi": "3.0.0",
+  "info": {"title": "Response Validation Test API", "version": "1.0.0"},
+  "paths": {
+    "/rows": {
+      "get": {
+        "summary": "List rows",
+        "x-table-name": "rows",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "required": ["id", "email"],
+                    "properties": {
+                      "id": {"type": "integer"},
+                      "email": {"type": "string", "format": "email"}
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  },
+  "x-table-schemas": [
+    {
+      "table_name": "rows",
+      "columns": [
+        {"name": "id", "column_type": "INTEGER", "primary_key": true, "auto_increment": true, "nullable": false, "unique": false},
+        {"name": "email", "column_type": "TEXT", "nullable": true, "primary_key": false, "unique": false, "auto_increment": false}
+      ],
+      "indexes": []
+    }
+  ]
+}
+`
+
+		configContent := fmt.Sprintf(`
+control-plane:
+  listen:
+    ip: 127.0.0.1
+    port: %s
+  database:
+    driver: sqlite
+    database: //%s
+
+listeners:
+  - port: %s
+    ip: 127.0.0.1
+    protocol: HTTP
+    apis:
+      - response-validation-api
+
+consumers:
+  - name: default
+    keys:
+      - test-key
+
+datasource:
+  sqlite1:
+    driver: sqlite
+    database: //%s
+    max_pool_size: 1
+
+log_level: "info"
+
+modules:
+  metrics:
+    enabled: true
+    port: %s
+  validation:
+    response:
+      mode: %s
+      sample_rate: %v
+`, serverPort, dbFile, serverPort, dbFile, metricsPort, mode, sampleRate)
+		Expect(os.WriteFile(configFile, []byte(configContent), 0644)).To(Succeed())
+
+		wd, _ := os.Getwd()
+		projectRoot := filepath.Dir(wd)
+
+		cpCmd = exec.Command("cargo", "run", "--bin", "apify-cp", "--", "--config", configFile)
+		cpCmd.Dir = projectRoot
+		cpCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+dbFile)
+		cpCmd.Stdout = GinkgoWriter
+		cpCmd.Stderr = GinkgoWriter
+		Expect(cpCmd.Start()).To(Succeed())
+
+		client = &http.Client{Timeout: 5 * time.Second}
+
+		Eventually(func() error {
+			resp, err := client.Get(baseURL + "/_meta/apis")
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != 200 {
+				return fmt.Errorf("status code %d", resp.StatusCode)
+			}
+			return nil
+		}, "300s", "1s").Should(Succeed())
+
+		var specObj map[string]interface{}
+		Expect(json.Unmarshal([]byte(apiSpecJSON), &specObj)).To(Succeed())
+		payload := map[string]interface{}{
+			"name":    "response-validation-api",
+			"version": "1.0.0",
+			"spec":    specObj,
+		}
+		payloadBytes, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := client.Post(baseURL+"/_meta/apis", "application/json", bytes.NewBuffer(payloadBytes))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(201))
+		resp.Body.Close()
+
+		seedBody, _ := json.Marshal(map[string]interface{}{"email": "not-an-email"})
+		seedResp, err := client.Post(baseURL+"/apify/admin/data/sqlite1/rows", "application/json", bytes.NewBuffer(seedBody))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(seedResp.StatusCode).To(Equal(http.StatusCreated))
+		seedResp.Body.Close()
+
+		if cpCmd.Process != nil {
+			cpCmd.Process.Kill()
+			cpCmd.Wait()
+		}
+
+		serverCmd = exec.Command("cargo", "run", "--bin", "apify", "--", "--config", configFile)
+		serverCmd.Dir = projectRoot
+		serverCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+dbFile)
+		serverCmd.Stdout = GinkgoWriter
+		serverCmd.Stderr = GinkgoWriter
+		Expect(serverCmd.Start()).To(Succeed())
+
+		Eventually(func() error {
+			resp, err := client.Get(baseURL + "/healthz")
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != 200 {
+				return fmt.Errorf("status %d", resp.StatusCode)
+			}
+			return nil
+		}, "120s", "1s").Should(Succeed(), "Server failed to start")
+	}
+
+	AfterEach(func() {
+		if serverCmd != nil && serverCmd.Process != nil {
+			serverCmd.Process.Kill()
+			serverCmd.Wait()
+		}
+		if cpCmd != nil && cpCmd.Process != nil {
+			cpCmd.Process.Kill()
+			cpCmd.Wait()
+		}
+		if tmpDir != "" {
+			os.RemoveAll(tmpDir)
+		}
+	})
+
+	It("should count a malformed response in apify_response_validation_failures_total under log mode", func() {
+		startWithMode("log", 1.0)
+
+		req, _ := http.NewRequest("GET", baseURL+"/rows", nil)
+		req.Header.Set("X-Api-Key", "test-key")
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		metricsResp, err := client.Get("http://127.0.0.1:" + metricsPort + "/metrics")
+		Expect(err).NotTo(HaveOccurred())
+		defer metricsResp.Body.Close()
+		data, err := io.ReadAll(metricsResp.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		body := string(data)
+		Expect(body).To(ContainSubstring("apify_response_validation_failures_total"))
+		Expect(body).To(ContainSubstring(`reason="format"`))
+	})
+
+	It("should swap the body for a 500 problem+json in enforce mode", func() {
+		startWithMode("enforce", 1.0)
+
+		req, _ := http.NewRequest("GET", baseURL+"/rows", nil)
+		req.Header.Set("X-Api-Key", "test-key")
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(resp.Header.Get("Content-Type")).To(ContainSubstring("application/problem+json"))
+
+		var problem map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&problem)).To(Succeed())
+		Expect(problem["status"]).To(BeNumerically("==", http.StatusInternalServerError))
+	})
+})
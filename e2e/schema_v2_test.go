@@ -0,0 +1,266 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("x-table-schema-v2 Alternate Table Representation", func() {
+	var (
+		env     *TestEnv
+		client  *http.Client
+		baseURL string
+	)
+
+	submitSpec := func(name, content string) *http.Response {
+		payload := map[string]string{
+			"name":    name,
+			"version": "1.0.0",
+			"spec":    content,
+		}
+		body, _ := json.Marshal(payload)
+		resp, err := client.Post(env.CPBaseURL+"/_meta/apis", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	v1 := `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      summary: Create product
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '200':
+          description: Created
+    get:
+      summary: List products
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+`
+
+	v2WithPartialIndex := `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      summary: Create product
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '200':
+          description: Created
+    get:
+      summary: List products
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        price:
+          type: number
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+      x-table-schema-v2:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+          - name: price
+            columnType: real
+            nullable: true
+        indexes:
+          - name: idx_products_price_not_null
+            columns: [price]
+            where: "price IS NOT NULL"
+        dialects:
+          postgres:
+            columns:
+              - name: price
+                columnType: numeric
+          sqlite:
+            columns:
+              - name: price
+                columnType: real
+`
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{"products": "api:products-api"})
+		baseURL = env.BaseURL
+		client = &http.Client{Timeout: 10 * time.Second}
+
+		resp := submitSpec("products-api", v1)
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+		resp.Body.Close()
+		time.Sleep(2 * time.Second)
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should prefer x-table-schema-v2 over x-table-schema and apply the partial index alongside the new column", func() {
+		product := map[string]interface{}{"name": "Laptop"}
+		body, _ := json.Marshal(product)
+		req, _ := http.NewRequest("POST", baseURL+"/products", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(200))
+		resp.Body.Close()
+
+		submitResp := submitSpec("products-api", v2WithPartialIndex)
+		Expect(submitResp.StatusCode).To(Or(Equal(200), Equal(201)))
+		submitResp.Body.Close()
+		time.Sleep(3 * time.Second)
+
+		product2 := map[string]interface{}{"name": "Mouse", "price": 29.99}
+		body, _ = json.Marshal(product2)
+		req, _ = http.NewRequest("POST", baseURL+"/products", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(200))
+		resp.Body.Close()
+
+		req, _ = http.NewRequest("GET", baseURL+"/products", nil)
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(200))
+
+		var products []map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&products)).To(Succeed())
+		Expect(products).To(HaveLen(2))
+		Expect(products[1]["price"]).To(Equal(29.99))
+	})
+
+	It("should warn when v1 and v2 column sets disagree", func() {
+		disagreeing := `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    get:
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+      x-table-schema-v2:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+          - name: extra_only_in_v2
+            columnType: text
+`
+		resp := submitSpec("products-api-mismatch", disagreeing)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+
+		var result map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&result)).To(Succeed())
+		warnings, ok := result["warnings"].([]interface{})
+		Expect(ok).To(BeTrue())
+		Expect(warnings).NotTo(BeEmpty())
+	})
+})
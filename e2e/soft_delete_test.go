@@ -0,0 +1,190 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Soft-Delete and Revision History", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		// orders.yaml in this fixture opts into soft-delete via the
+		// `x-soft-delete: true` OpenAPI extension, mirroring how
+		// x-upstream-url/x-retry are declared elsewhere in this suite.
+		env = StartTestEnv(map[string]string{
+			"orders": "examples/relations/config/openapi/orders.yaml",
+			"users":  "examples/relations/config/openapi/users.yaml",
+		})
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	createOrder := func(customerName string) int {
+		body := map[string]interface{}{
+			"customerName": customerName,
+			"total":        25.00,
+			"status":       "pending",
+			"items": []map[string]interface{}{
+				{"productName": "Revisioned Widget", "quantity": 1, "price": 25.00},
+			},
+		}
+		payload, _ := json.Marshal(body)
+		resp, err := client.Post(env.BaseURL+"/orders", "application/json", bytes.NewBuffer(payload))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		var created map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&created)).To(Succeed())
+		return int(created["id"].(float64))
+	}
+
+	It("should set deletedAt and exclude the row from LIST/GET instead of removing it", func() {
+		orderID := createOrder("Soft Delete Customer")
+
+		req, err := http.NewRequest("DELETE", env.BaseURL+fmt.Sprintf("/orders/%d", orderID), nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+
+		resp, err = client.Get(env.BaseURL + fmt.Sprintf("/orders/%d", orderID))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+
+		resp, err = client.Get(env.BaseURL + fmt.Sprintf("/orders/%d?withDeleted=true", orderID))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var order map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&order)).To(Succeed())
+		Expect(order["deletedAt"]).NotTo(BeNil())
+	})
+
+	It("should restore a soft-deleted resource via POST /{resource}/{id}/restore", func() {
+		orderID := createOrder("Restore Customer")
+
+		req, err := http.NewRequest("DELETE", env.BaseURL+fmt.Sprintf("/orders/%d", orderID), nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+
+		req, err = http.NewRequest("POST", env.BaseURL+fmt.Sprintf("/orders/%d/restore", orderID), nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		resp, err = client.Get(env.BaseURL + fmt.Sprintf("/orders/%d", orderID))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var order map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&order)).To(Succeed())
+		Expect(order["deletedAt"]).To(BeNil())
+	})
+
+	It("should cascade soft-delete to children and record a linked revision for each", func() {
+		orderID := createOrder("Cascade Soft Delete Customer")
+
+		req, err := http.NewRequest("DELETE", env.BaseURL+fmt.Sprintf("/orders/%d", orderID), nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+
+		resp, err = client.Get(env.BaseURL + fmt.Sprintf("/orders/%d?withDeleted=true&include=items", orderID))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var order map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&order)).To(Succeed())
+		items := order["items"].([]interface{})
+		Expect(items).NotTo(BeEmpty())
+		for _, i := range items {
+			Expect(i.(map[string]interface{})["deletedAt"]).NotTo(BeNil())
+		}
+
+		resp, err = client.Get(env.BaseURL + fmt.Sprintf("/orders/%d/revisions", orderID))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var revisions []map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&revisions)).To(Succeed())
+		Expect(revisions).NotTo(BeEmpty())
+	})
+
+	It("should write a revision on every update, including a snapshot of nested hasMany items", func() {
+		orderID := createOrder("Revision Customer")
+
+		updateBody := map[string]interface{}{
+			"customerName": "Revision Customer",
+			"total":        99.00,
+			"status":       "shipped",
+			"items": []map[string]interface{}{
+				{"productName": "Replacement Widget", "quantity": 2, "price": 49.50},
+			},
+		}
+		payload, _ := json.Marshal(updateBody)
+		req, err := http.NewRequest("PUT", env.BaseURL+fmt.Sprintf("/orders/%d", orderID), bytes.NewBuffer(payload))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		resp, err = client.Get(env.BaseURL + fmt.Sprintf("/orders/%d/revisions", orderID))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var revisions []map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&revisions)).To(Succeed())
+		Expect(revisions).To(HaveLen(1))
+
+		rev := revisions[0]["rev"]
+		resp, err = client.Get(env.BaseURL + fmt.Sprintf("/orders/%d/revisions/%v", orderID, rev))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var snapshot map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&snapshot)).To(Succeed())
+		Expect(snapshot["status"]).To(Equal("pending"))
+
+		snapshotItems := snapshot["items"].([]interface{})
+		Expect(snapshotItems).To(HaveLen(1))
+		Expect(snapshotItems[0].(map[string]interface{})["productName"]).To(Equal("Revisioned Widget"))
+	})
+})
@@ -0,0 +1,277 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Spec Version History and Rollback", func() {
+	var (
+		env     *TestEnv
+		client  *http.Client
+		baseURL string
+	)
+
+	submitSpec := func(content string) *http.Response {
+		payload := map[string]string{
+			"name":    "products-api",
+			"version": "1.0.0",
+			"spec":    content,
+		}
+		body, _ := json.Marshal(payload)
+		resp, err := client.Post(env.CPBaseURL+"/_meta/apis?allow_destructive=true", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	v1 := `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '200':
+          description: Created
+    get:
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+`
+
+	v2 := `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '200':
+          description: Created
+    get:
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        price:
+          type: number
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+          - name: price
+            columnType: real
+            nullable: true
+`
+
+	v3 := `
+openapi: 3.0.0
+info:
+  title: Products API
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '200':
+          description: Created
+    get:
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        price:
+          type: number
+        sku:
+          type: string
+      x-table-schema:
+        tableName: products
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+            autoIncrement: true
+          - name: name
+            columnType: text
+            nullable: false
+          - name: price
+            columnType: real
+            nullable: true
+          - name: sku
+            columnType: text
+            nullable: true
+`
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{"products": "api:products-api"})
+		baseURL = env.BaseURL
+		client = &http.Client{Timeout: 10 * time.Second}
+
+		resp := submitSpec(v1)
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+		resp.Body.Close()
+		time.Sleep(2 * time.Second)
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should track every accepted submission and expose it via /_meta/apis/{name}/versions", func() {
+		resp := submitSpec(v2)
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+		resp.Body.Close()
+		time.Sleep(2 * time.Second)
+
+		resp = submitSpec(v3)
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+		resp.Body.Close()
+		time.Sleep(2 * time.Second)
+
+		versionsResp, err := client.Get(env.CPBaseURL + "/_meta/apis/products-api/versions")
+		Expect(err).NotTo(HaveOccurred())
+		defer versionsResp.Body.Close()
+		Expect(versionsResp.StatusCode).To(Equal(http.StatusOK))
+
+		var versions []map[string]interface{}
+		Expect(json.NewDecoder(versionsResp.Body).Decode(&versions)).To(Succeed())
+		Expect(len(versions)).To(BeNumerically(">=", 3))
+
+		oneResp, err := client.Get(env.CPBaseURL + "/_meta/apis/products-api/versions/1")
+		Expect(err).NotTo(HaveOccurred())
+		defer oneResp.Body.Close()
+		Expect(oneResp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should roll back to v1, dropping the columns added since, and re-activate it for routing", func() {
+		resp := submitSpec(v2)
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+		resp.Body.Close()
+		time.Sleep(2 * time.Second)
+
+		product := map[string]interface{}{"name": "Laptop", "price": 999.00}
+		body, _ := json.Marshal(product)
+		req, _ := http.NewRequest("POST", baseURL+"/products", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Api-Key", env.APIKey)
+		createResp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(createResp.StatusCode).To(Equal(200))
+		createResp.Body.Close()
+
+		resp = submitSpec(v3)
+		Expect(resp.StatusCode).To(Or(Equal(200), Equal(201)))
+		resp.Body.Close()
+		time.Sleep(2 * time.Second)
+
+		rollbackBody, _ := json.Marshal(map[string]int{"toVersion": 1})
+		rollbackResp, err := client.Post(env.CPBaseURL+"/_meta/apis/products-api/rollback", "application/json", bytes.NewBuffer(rollbackBody))
+		Expect(err).NotTo(HaveOccurred())
+		defer rollbackResp.Body.Close()
+		Expect(rollbackResp.StatusCode).To(Equal(http.StatusOK))
+
+		time.Sleep(3 * time.Second)
+
+		req, _ = http.NewRequest("GET", baseURL+"/products", nil)
+		req.Header.Set("X-Api-Key", env.APIKey)
+		listResp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer listResp.Body.Close()
+		Expect(listResp.StatusCode).To(Equal(200))
+
+		var products []map[string]interface{}
+		Expect(json.NewDecoder(listResp.Body).Decode(&products)).To(Succeed())
+		Expect(products).To(HaveLen(1))
+		Expect(products[0]["name"]).To(Equal("Laptop"))
+		Expect(products[0]).NotTo(HaveKey("price"))
+		Expect(products[0]).NotTo(HaveKey("sku"))
+	})
+})
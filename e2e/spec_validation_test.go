@@ -0,0 +1,196 @@
+package e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Aggregated Spec Validation on /_meta/apis", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	submitSpec := func(query, name, content string) *http.Response {
+		payload := map[string]string{
+			"name":    name,
+			"version": "1.0.0",
+			"spec":    content,
+		}
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest("POST", env.CPBaseURL+"/_meta/apis"+query, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{})
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should collect every problem in a spec into a single {errors, warnings} response with 422", func() {
+		brokenSpec := `
+openapi: 3.0.0
+info:
+  title: Broken API
+  version: 1.0.0
+paths:
+  /widgets:
+    get:
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/DoesNotExist'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Widget'
+      responses:
+        '200':
+          description: Created
+components:
+  schemas:
+    Widget:
+      type: object
+      properties:
+        id:
+          type: integer
+      x-table-schema:
+        tableName: widgets
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+          - name: id
+            columnType: text
+`
+		resp := submitSpec("", "broken-api", brokenSpec)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+
+		var result map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&result)).To(Succeed())
+
+		errs := result["errors"].([]interface{})
+		// Both the unknown $ref and the duplicate column name should be
+		// reported together in one round trip, not one-at-a-time.
+		Expect(len(errs)).To(BeNumerically(">=", 2))
+
+		for _, e := range errs {
+			entry := e.(map[string]interface{})
+			Expect(entry).To(HaveKey("path"))
+			Expect(entry).To(HaveKey("code"))
+			Expect(entry).To(HaveKey("message"))
+		}
+	})
+
+	It("should accept a valid spec with an empty errors list", func() {
+		okSpec := `
+openapi: 3.0.0
+info:
+  title: Widgets API
+  version: 1.0.0
+paths:
+  /widgets:
+    get:
+      operationId: listWidgets
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Widget'
+components:
+  schemas:
+    Widget:
+      type: object
+      properties:
+        id:
+          type: integer
+      x-table-schema:
+        tableName: widgets
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+`
+		resp := submitSpec("", "widgets-api", okSpec)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Or(Equal(http.StatusOK), Equal(http.StatusCreated)))
+
+		var result map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&result)).To(Succeed())
+		Expect(result["errors"]).To(Or(BeNil(), BeEmpty()))
+	})
+
+	It("should promote warnings to errors when ?strict=true is set", func() {
+		warnOnlySpec := `
+openapi: 3.0.0
+info:
+  title: Gadgets API
+  version: 1.0.0
+paths:
+  /gadgets:
+    get:
+      responses:
+        '200':
+          description: List
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Gadget'
+components:
+  schemas:
+    Gadget:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+      x-table-schema:
+        tableName: gadgets
+        columns:
+          - name: id
+            columnType: integer
+            primaryKey: true
+`
+		lenient := submitSpec("", "gadgets-api", warnOnlySpec)
+		defer lenient.Body.Close()
+		Expect(lenient.StatusCode).To(Or(Equal(http.StatusOK), Equal(http.StatusCreated)))
+
+		strict := submitSpec("?strict=true", "gadgets-api-strict", warnOnlySpec)
+		defer strict.Body.Close()
+		Expect(strict.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+
+		var result map[string]interface{}
+		Expect(json.NewDecoder(strict.Body).Decode(&result)).To(Succeed())
+		errs := result["errors"].([]interface{})
+		Expect(errs).NotTo(BeEmpty())
+	})
+})
@@ -0,0 +1,133 @@
+package e2e_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// startTCPEchoServer listens on an ephemeral loopback port and echoes back
+// every line it receives, simulating a plain-text backend a TCP listener
+// would forward to.
+func startTCPEchoServer() (addr string, stop func()) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(c net.Conn) {
+				defer c.Close()
+				scanner := bufio.NewScanner(c)
+				for scanner.Scan() {
+					fmt.Fprintf(c, "echo: %s\n", scanner.Text())
+				}
+			}(conn)
+		}
+	}()
+	return ln.Addr().String(), func() {
+		close(done)
+		ln.Close()
+	}
+}
+
+var _ = Describe("TCP/UDP Passthrough Listeners", func() {
+	var (
+		env        *TestEnv
+		client     *http.Client
+		echoAddr   string
+		stopEcho   func()
+		dpHost     string
+		tcpPort    int
+		unusedPort int
+	)
+
+	BeforeEach(func() {
+		var err error
+		env, client, err = SetupControlPlaneEnv()
+		Expect(err).NotTo(HaveOccurred())
+
+		echoAddr, stopEcho = startTCPEchoServer()
+
+		l1, _ := net.Listen("tcp", "127.0.0.1:0")
+		tcpPort = l1.Addr().(*net.TCPAddr).Port
+		l1.Close()
+
+		l2, _ := net.Listen("tcp", "127.0.0.1:0")
+		unusedPort = l2.Addr().(*net.TCPAddr).Port
+		l2.Close()
+
+		dpHost = "127.0.0.1"
+	})
+
+	AfterEach(func() {
+		if stopEcho != nil {
+			stopEcho()
+		}
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should forward raw TCP traffic only on the bound listener", func() {
+		listenerConfig := map[string]interface{}{
+			"name":     "tcp-echo-listener",
+			"port":     tcpPort,
+			"ip":       dpHost,
+			"protocol": "TCP",
+		}
+		body, _ := json.Marshal(listenerConfig)
+		resp, err := client.Post(env.CPBaseURL+"/apify/admin/listeners", "application/json", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+		apiConfig := map[string]interface{}{
+			"name":    "tcp-echo-route",
+			"version": "1.0.0",
+			"spec": map[string]interface{}{
+				"openapi": "3.0.0",
+				"info":    map[string]interface{}{"title": "TCP Echo Route", "version": "1.0.0"},
+				"paths":   map[string]interface{}{},
+				"x-tcp-route": map[string]interface{}{
+					"address":         echoAddr,
+					"connect_timeout": "2s",
+					"idle_timeout":    "30s",
+				},
+			},
+			"listeners": []string{"tcp-echo-listener"},
+		}
+		body2, _ := json.Marshal(apiConfig)
+		resp2, err := client.Post(env.CPBaseURL+"/apify/admin/apis", "application/json", bytes.NewBuffer(body2))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp2.Body.Close()
+		Expect(resp2.StatusCode).To(Equal(http.StatusCreated))
+
+		var conn net.Conn
+		Eventually(func() error {
+			var dialErr error
+			conn, dialErr = net.DialTimeout("tcp", fmt.Sprintf("%s:%d", dpHost, tcpPort), 2*time.Second)
+			return dialErr
+		}, "10s", "500ms").Should(Succeed())
+		defer conn.Close()
+
+		fmt.Fprintf(conn, "hello\n")
+		reply, err := bufio.NewReader(conn).ReadString('\n')
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reply).To(Equal("echo: hello\n"))
+
+		_, err = net.DialTimeout("tcp", fmt.Sprintf("%s:%d", dpHost, unusedPort), 1*time.Second)
+		Expect(err).To(HaveOccurred())
+	})
+})
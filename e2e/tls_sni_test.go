@@ -0,0 +1,299 @@
+package e2e_test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+)
+
+// generateSelfSignedCert creates a throwaway server certificate for the given
+// hostname, used to exercise SNI-based certificate selection.
+func generateSelfSignedCert(host string) (certFile, keyFile string, dir string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	dir, err = os.MkdirTemp("", "apify-sni-cert-"+host)
+	Expect(err).NotTo(HaveOccurred())
+
+	certFile = filepath.Join(dir, host+".crt")
+	keyFile = filepath.Join(dir, host+".key")
+
+	certOut, err := os.Create(certFile)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: der})).To(Succeed())
+	Expect(certOut.Close()).To(Succeed())
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+	keyOut, err := os.Create(keyFile)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pem.Encode(keyOut, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})).To(Succeed())
+	Expect(keyOut.Close()).To(Succeed())
+
+	return certFile, keyFile, dir
+}
+
+var _ = Describe("TLS/SNI Listener Routing", func() {
+	var (
+		cpCmd    *exec.Cmd
+		dpCmd    *exec.Cmd
+		tmpDir   string
+		certDirs []string
+		httpsURL string
+		cpURL    string
+		client   *http.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		wd, _ := os.Getwd()
+		projectRoot := filepath.Dir(wd)
+
+		getFreePort := func() int {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			defer l.Close()
+			return l.Addr().(*net.TCPAddr).Port
+		}
+
+		cpPort := getFreePort()
+		dpPort := getFreePort()
+		metricsPort := getFreePort()
+		cpURL = fmt.Sprintf("http://127.0.0.1:%d", cpPort)
+		httpsURL = fmt.Sprintf("https://127.0.0.1:%d", dpPort)
+
+		tmpDir, err = os.MkdirTemp("", "apify-sni-test")
+		Expect(err).NotTo(HaveOccurred())
+		dbFile := filepath.Join(tmpDir, "test.sqlite")
+		f, err := os.Create(dbFile)
+		Expect(err).NotTo(HaveOccurred())
+		f.Close()
+
+		certA, keyA, dirA := generateSelfSignedCert("tenant-a.example.com")
+		certB, keyB, dirB := generateSelfSignedCert("tenant-b.example.com")
+		certDirs = []string{dirA, dirB}
+
+		createSpec := func(name, tableName, hostname string) string {
+			content := fmt.Sprintf(`openapi: "3.0.0"
+info:
+  title: "%s"
+  version: "1.0.0"
+x-hostnames:
+  - "%s"
+x-table-schemas:
+  - table_name: "%s"
+    columns:
+      - { name: "id", column_type: "INTEGER", nullable: false, primary_key: true, unique: false, auto_increment: true, default_value: null }
+    indexes: []
+paths:
+  /%s:
+    get:
+      x-table-name: "%s"
+      responses:
+        "200":
+          description: "ok"
+`, name, hostname, tableName, name, tableName)
+			path := filepath.Join(tmpDir, name+".yaml")
+			Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+			return path
+		}
+
+		pathA := createSpec("tenanta", "tenanta_items", "tenant-a.example.com")
+		pathB := createSpec("tenantb", "tenantb_items", "tenant-b.example.com")
+
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		configContent := fmt.Sprintf(`
+control-plane:
+  listen:
+    ip: 127.0.0.1
+    port: %d
+  database:
+    driver: sqlite
+    database: //%s
+
+log_level: "info"
+
+modules:
+  metrics:
+    enabled: true
+    port: %d
+`, cpPort, dbFile, metricsPort)
+		Expect(os.WriteFile(configFile, []byte(configContent), 0644)).To(Succeed())
+
+		cpCmd = exec.Command("cargo", "run", "--bin", "apify", "--", "--control-plane", "--config", configFile)
+		cpCmd.Dir = projectRoot
+		cpCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+dbFile)
+		cpCmd.Stdout = GinkgoWriter
+		cpCmd.Stderr = GinkgoWriter
+		Expect(cpCmd.Start()).To(Succeed())
+
+		client = &http.Client{
+			Timeout:   5 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		}
+
+		Eventually(func() error {
+			resp, err := http.Get(cpURL + "/_meta/apis")
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != 200 {
+				return fmt.Errorf("status %d", resp.StatusCode)
+			}
+			return nil
+		}, "60s", "1s").Should(Succeed())
+
+		importConfig := map[string]interface{}{
+			"datasource": map[string]interface{}{
+				"default": map[string]interface{}{
+					"driver":        "sqlite",
+					"database":      "//" + dbFile,
+					"max_pool_size": 1,
+				},
+			},
+			"listeners": []map[string]interface{}{
+				{
+					"name":     "https-entrypoint",
+					"port":     dpPort,
+					"ip":       "127.0.0.1",
+					"protocol": "HTTPS",
+					"tls": map[string]interface{}{
+						"min_version": "1.2",
+						"certificates": []map[string]interface{}{
+							{"cert_file": certA, "key_file": keyA},
+							{"cert_file": certB, "key_file": keyB},
+						},
+					},
+				},
+			},
+			"apis": []map[string]interface{}{
+				{"path": pathA, "listeners": []string{"https-entrypoint"}},
+				{"path": pathB, "listeners": []string{"https-entrypoint"}},
+			},
+		}
+		importYaml, err := yaml.Marshal(importConfig)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := http.Post(cpURL+"/_meta/import", "application/x-yaml", bytes.NewBuffer(importYaml))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(200))
+		resp.Body.Close()
+
+		dpCmd = exec.Command("cargo", "run", "--bin", "apify", "--", "--data-plane", "--config", configFile)
+		dpCmd.Dir = projectRoot
+		dpCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+dbFile, "APIFY_CONFIG_POLL_INTERVAL=1")
+		dpCmd.Stdout = GinkgoWriter
+		dpCmd.Stderr = GinkgoWriter
+		Expect(dpCmd.Start()).To(Succeed())
+
+		Eventually(func() error {
+			req, _ := http.NewRequest("GET", httpsURL+"/healthz", nil)
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != 200 {
+				return fmt.Errorf("status %d", resp.StatusCode)
+			}
+			return nil
+		}, "60s", "1s").Should(Succeed())
+	})
+
+	AfterEach(func() {
+		if dpCmd != nil && dpCmd.Process != nil {
+			dpCmd.Process.Kill()
+			dpCmd.Wait()
+		}
+		if cpCmd != nil && cpCmd.Process != nil {
+			cpCmd.Process.Kill()
+			cpCmd.Wait()
+		}
+		if tmpDir != "" {
+			os.RemoveAll(tmpDir)
+		}
+		for _, d := range certDirs {
+			os.RemoveAll(d)
+		}
+	})
+
+	requestWithSNI := func(hostname, path string) *http.Response {
+		dialer := &net.Dialer{Timeout: 5 * time.Second}
+		conn, err := tls.DialWithDialer(dialer, "tcp", httpsURL[len("https://"):], &tls.Config{
+			InsecureSkipVerify: true,
+			ServerName:         hostname,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+
+		Expect(conn.ConnectionState().ServerName).To(Equal(hostname))
+
+		req, err := http.NewRequest("GET", "https://"+hostname+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Write(conn)).To(Succeed())
+
+		resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+		Expect(err).NotTo(HaveOccurred())
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return resp
+	}
+
+	It("should route to the API whose x-hostnames matches the SNI server name", func() {
+		respA := requestWithSNI("tenant-a.example.com", "/tenanta")
+		Expect(respA.StatusCode).To(Equal(http.StatusOK))
+
+		respB := requestWithSNI("tenant-b.example.com", "/tenantb")
+		Expect(respB.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should not serve a hostname's API to a request presenting a different SNI", func() {
+		resp := requestWithSNI("tenant-b.example.com", "/tenanta")
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	It("should present the matching certificate's common name per SNI server name", func() {
+		dialer := &net.Dialer{Timeout: 5 * time.Second}
+		conn, err := tls.DialWithDialer(dialer, "tcp", httpsURL[len("https://"):], &tls.Config{
+			InsecureSkipVerify: true,
+			ServerName:         "tenant-a.example.com",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+
+		certs := conn.ConnectionState().PeerCertificates
+		Expect(certs).NotTo(BeEmpty())
+		Expect(certs[0].Subject.CommonName).To(Equal("tenant-a.example.com"))
+	})
+})
@@ -0,0 +1,79 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OpenTelemetry Tracing", func() {
+	var (
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		env = StartTestEnv(map[string]string{})
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+	})
+
+	It("should accept and propagate a W3C traceparent header", func() {
+		req, err := http.NewRequest("GET", env.BaseURL+"/healthz", nil)
+		Expect(err).NotTo(HaveOccurred())
+		traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+		req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should include the active trace id in error responses for support triage", func() {
+		req, err := http.NewRequest("GET", env.BaseURL+"/items/does-not-exist", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+
+		var body map[string]interface{}
+		Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+		errObj, ok := body["error"].(map[string]interface{})
+		Expect(ok).To(BeTrue())
+		Expect(errObj["trace_id"]).NotTo(BeEmpty())
+	})
+
+	Describe("Admin tracing toggle", func() {
+		It("should flip the sampling ratio live without a config reload", func() {
+			cpClient := &http.Client{Timeout: 5 * time.Second}
+			payload := strings.NewReader(`{"sampler":"traceidratio","ratio":0.5}`)
+			resp, err := cpClient.Post(env.CPBaseURL+"/apify/admin/tracing", "application/json", payload)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			getResp, err := cpClient.Get(env.CPBaseURL + "/apify/admin/tracing")
+			Expect(err).NotTo(HaveOccurred())
+			defer getResp.Body.Close()
+			Expect(getResp.StatusCode).To(Equal(http.StatusOK))
+
+			var cfg map[string]interface{}
+			Expect(json.NewDecoder(getResp.Body).Decode(&cfg)).To(Succeed())
+			Expect(cfg["sampler"]).To(Equal("traceidratio"))
+			Expect(cfg["ratio"]).To(Equal(0.5))
+		})
+	})
+})
@@ -0,0 +1,76 @@
+package e2e_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/apifyhost/apify/e2e/upstreamsvr"
+)
+
+var _ = Describe("Upstream Contract Verification", func() {
+	var (
+		env      *TestEnv
+		upstream *ghttp.Server
+		client   *http.Client
+	)
+
+	BeforeEach(func() {
+		upstream = upstreamsvr.New()
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+		upstream.Close()
+	})
+
+	It("should forward the request to the mock upstream with auth stripped and headers propagated", func() {
+		upstream.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/widgets"),
+				ghttp.VerifyHeaderKV("X-Request-Id", "contract-test-1"),
+				func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.Header.Get("X-Api-Key")).To(BeEmpty())
+				},
+				ghttp.RespondWith(http.StatusOK, `[{"id":1,"name":"widget"}]`),
+			),
+		)
+
+		specDir, err := os.MkdirTemp("", "apify-upstream-contract-test")
+		Expect(err).NotTo(HaveOccurred())
+		specPath := filepath.Join(specDir, "widgets.yaml")
+		Expect(os.WriteFile(specPath, []byte(`openapi: "3.0.0"
+info:
+  title: "Widgets"
+  version: "1.0.0"
+paths:
+  /widgets:
+    get:
+      x-proxy: true
+      responses:
+        "200":
+          description: "ok"
+`), 0644)).To(Succeed())
+
+		env = StartTestEnv(map[string]string{"widgets-api": specPath}, map[string]*ghttp.Server{"widgets-api": upstream})
+
+		req, err := http.NewRequest("GET", env.BaseURL+"/widgets", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Api-Key", env.APIKey)
+		req.Header.Set("X-Request-Id", "contract-test-1")
+
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(upstream.ReceivedRequests()).To(HaveLen(1))
+	})
+})
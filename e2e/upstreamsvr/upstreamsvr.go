@@ -0,0 +1,14 @@
+// Package upstreamsvr wraps gomega/ghttp so e2e suites can stand a
+// controlled mock in for the real backend an OpenAPI operation would
+// otherwise proxy to, and assert exactly what the data plane forwards
+// upstream after auth, header rewrites, and rate limiting.
+package upstreamsvr
+
+import "github.com/onsi/gomega/ghttp"
+
+// New starts a ghttp-backed mock upstream server. Callers register
+// expectations with AppendHandlers (e.g. ghttp.VerifyRequest, ghttp.VerifyJSON,
+// ghttp.RespondWith) the same way they would against any ghttp.Server.
+func New() *ghttp.Server {
+	return ghttp.NewServer()
+}
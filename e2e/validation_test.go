@@ -17,14 +17,14 @@ import (
 
 var _ = Describe("OpenAPI Validation", func() {
 	var (
-		serverCmd   *exec.Cmd
-		cpCmd       *exec.Cmd
-		serverPort  string
-		baseURL     string
-		configFile  string
-		dbFile      string
-		client      *http.Client
-		tmpDir      string
+		serverCmd  *exec.Cmd
+		cpCmd      *exec.Cmd
+		serverPort string
+		baseURL    string
+		configFile string
+		dbFile     string
+		client     *http.Client
+		tmpDir     string
 	)
 
 	BeforeEach(func() {
@@ -202,6 +202,8 @@ modules:
     enabled: true
   metrics:
     enabled: false
+  validation:
+    error_format: problem+json
 `, serverPort, dbFile, serverPort, dbFile)
 		err = os.WriteFile(configFile, []byte(configContent), 0644)
 		Expect(err).NotTo(HaveOccurred())
@@ -214,13 +216,13 @@ modules:
 		// Start Server (Control Plane)
 		wd, _ := os.Getwd()
 		projectRoot := filepath.Dir(wd)
-		
+
 		cpCmd = exec.Command("cargo", "run", "--bin", "apify-cp", "--", "--config", configFile)
 		cpCmd.Dir = projectRoot
 		cpCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+dbFile)
 		cpCmd.Stdout = GinkgoWriter
 		cpCmd.Stderr = GinkgoWriter
-		
+
 		err = cpCmd.Start()
 		Expect(err).NotTo(HaveOccurred())
 
@@ -270,7 +272,7 @@ modules:
 		serverCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+dbFile)
 		serverCmd.Stdout = GinkgoWriter
 		serverCmd.Stderr = GinkgoWriter
-		
+
 		err = serverCmd.Start()
 		Expect(err).NotTo(HaveOccurred())
 
@@ -307,7 +309,7 @@ modules:
 		req, _ := http.NewRequest("POST", baseURL+"/users?dry_run=true&limit=10", bytes.NewBuffer(body))
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("x-request-id", "12345")
-		
+
 		resp, err := client.Do(req)
 		Expect(err).NotTo(HaveOccurred())
 		defer resp.Body.Close()
@@ -319,11 +321,25 @@ modules:
 		req, _ := http.NewRequest("POST", baseURL+"/users", bytes.NewBuffer(body))
 		req.Header.Set("Content-Type", "application/json")
 		// Missing x-request-id
-		
+
 		resp, err := client.Do(req)
 		Expect(err).NotTo(HaveOccurred())
 		defer resp.Body.Close()
 		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(resp.Header.Get("Content-Type")).To(ContainSubstring("application/problem+json"))
+
+		problem := decodeProblem(resp)
+		Expect(problem["status"]).To(BeNumerically("==", http.StatusBadRequest))
+		Expect(problem["type"]).NotTo(BeEmpty())
+		Expect(problem["title"]).NotTo(BeEmpty())
+		Expect(problem["instance"]).To(Equal("/users"))
+
+		errs := problem["errors"].([]interface{})
+		Expect(errs).To(HaveLen(1))
+		errEntry := errs[0].(map[string]interface{})
+		Expect(errEntry["in"]).To(Equal("header"))
+		Expect(errEntry["pointer"]).To(Equal("/header/x-request-id"))
+		Expect(errEntry["keyword"]).To(Equal("required"))
 	})
 
 	It("should reject requests with invalid header format", func() {
@@ -331,11 +347,20 @@ modules:
 		req, _ := http.NewRequest("POST", baseURL+"/users", bytes.NewBuffer(body))
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("x-request-id", "123") // Too short (min 5)
-		
+
 		resp, err := client.Do(req)
 		Expect(err).NotTo(HaveOccurred())
 		defer resp.Body.Close()
 		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+		problem := decodeProblem(resp)
+		errs := problem["errors"].([]interface{})
+		Expect(errs).To(HaveLen(1))
+		errEntry := errs[0].(map[string]interface{})
+		Expect(errEntry["in"]).To(Equal("header"))
+		Expect(errEntry["pointer"]).To(Equal("/header/x-request-id"))
+		Expect(errEntry["keyword"]).To(Equal("minLength"))
+		Expect(errEntry["value"]).To(Equal("123"))
 	})
 
 	It("should reject requests with invalid query param type", func() {
@@ -343,22 +368,46 @@ modules:
 		req, _ := http.NewRequest("POST", baseURL+"/users?limit=notanumber", bytes.NewBuffer(body))
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("x-request-id", "12345")
-		
+
 		resp, err := client.Do(req)
 		Expect(err).NotTo(HaveOccurred())
 		defer resp.Body.Close()
 		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+		problem := decodeProblem(resp)
+		errs := problem["errors"].([]interface{})
+		Expect(errs).To(HaveLen(1))
+		errEntry := errs[0].(map[string]interface{})
+		Expect(errEntry["in"]).To(Equal("query"))
+		Expect(errEntry["pointer"]).To(Equal("/query/limit"))
+		Expect(errEntry["keyword"]).To(Equal("type"))
 	})
-	
+
 	It("should reject requests with invalid query param constraint", func() {
 		body := []byte(`{"name": "Alice", "email": "alice@example.com"}`)
 		req, _ := http.NewRequest("POST", baseURL+"/users?limit=0", bytes.NewBuffer(body)) // min 1
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("x-request-id", "12345")
-		
+
 		resp, err := client.Do(req)
 		Expect(err).NotTo(HaveOccurred())
 		defer resp.Body.Close()
 		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+		problem := decodeProblem(resp)
+		errs := problem["errors"].([]interface{})
+		Expect(errs).To(HaveLen(1))
+		errEntry := errs[0].(map[string]interface{})
+		Expect(errEntry["in"]).To(Equal("query"))
+		Expect(errEntry["pointer"]).To(Equal("/query/limit"))
+		Expect(errEntry["keyword"]).To(Equal("minimum"))
 	})
 })
+
+// decodeProblem reads and unmarshals an RFC 7807 application/problem+json
+// body, failing the spec if the body isn't valid JSON.
+func decodeProblem(resp *http.Response) map[string]interface{} {
+	var problem map[string]interface{}
+	ExpectWithOffset(1, json.NewDecoder(resp.Body).Decode(&problem)).To(Succeed())
+	return problem
+}
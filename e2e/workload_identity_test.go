@@ -0,0 +1,352 @@
+package e2e_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+)
+
+// startWorkloadIdentityEnv boots a Control Plane + Data Plane pair with the
+// given workload-identity auth provider config, wired up to the "items" API
+// exactly like StartTestEnv's default fixture.
+func startWorkloadIdentityEnv(providerYAML string) *TestEnv {
+	env := &TestEnv{}
+
+	wd, _ := os.Getwd()
+	projectRoot := filepath.Dir(wd)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	dpPort := fmt.Sprintf("%d", l.Addr().(*net.TCPAddr).Port)
+	l.Close()
+	env.BaseURL = "http://127.0.0.1:" + dpPort
+
+	l, err = net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	cpPort := fmt.Sprintf("%d", l.Addr().(*net.TCPAddr).Port)
+	l.Close()
+	env.CPBaseURL = "http://127.0.0.1:" + cpPort
+
+	env.TmpDir, err = os.MkdirTemp("", "apify-e2e-identity")
+	Expect(err).NotTo(HaveOccurred())
+	env.ConfigFile = filepath.Join(env.TmpDir, "config.yaml")
+	env.DBFile = filepath.Join(env.TmpDir, "test.sqlite")
+
+	f, err := os.Create(env.DBFile)
+	Expect(err).NotTo(HaveOccurred())
+	f.Close()
+
+	env.APIKey = "e2e-test-key-001"
+	configContent := fmt.Sprintf(`
+control-plane:
+  listen:
+    ip: 127.0.0.1
+    port: %s
+  database:
+    driver: sqlite
+    database: //%s
+
+listeners:
+  - port: %s
+    ip: 127.0.0.1
+    protocol: HTTP
+    apis: []
+
+auth:
+  - name: e2e-api-keys
+    type: api-key
+    enabled: true
+    config:
+      source: header
+      key_name: X-Api-Key
+      consumers:
+        - name: default
+          keys:
+            - %s
+%s
+
+datasource:
+  default:
+    driver: sqlite
+    database: //%s
+    max_pool_size: 1
+
+log_level: "info"
+`, cpPort, env.DBFile, dpPort, env.APIKey, providerYAML, env.DBFile)
+
+	Expect(os.WriteFile(env.ConfigFile, []byte(configContent), 0644)).To(Succeed())
+
+	env.CPCmd = exec.Command("cargo", "run", "--bin", "apify", "--", "--control-plane", "--config", env.ConfigFile)
+	env.CPCmd.Dir = projectRoot
+	env.CPCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+env.DBFile)
+	env.CPCmd.Stdout = GinkgoWriter
+	env.CPCmd.Stderr = GinkgoWriter
+	Expect(env.CPCmd.Start()).To(Succeed())
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	Eventually(func() error {
+		resp, err := client.Get(env.CPBaseURL + "/_meta/apis")
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("status code %d", resp.StatusCode)
+		}
+		return nil
+	}, 60*time.Second, 1*time.Second).Should(Succeed())
+
+	specContent, err := os.ReadFile(filepath.Join(projectRoot, "examples/basic/config/openapi/items.yaml"))
+	Expect(err).NotTo(HaveOccurred())
+	var specObj map[string]interface{}
+	Expect(yaml.Unmarshal(specContent, &specObj)).To(Succeed())
+
+	payloadBytes, err := json.Marshal(map[string]interface{}{
+		"name":    "items",
+		"version": "1.0.0",
+		"spec":    specObj,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	resp, err := client.Post(env.CPBaseURL+"/_meta/apis", "application/json", bytes.NewBuffer(payloadBytes))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(201))
+	resp.Body.Close()
+
+	dpPortInt, err := strconv.Atoi(dpPort)
+	Expect(err).NotTo(HaveOccurred())
+	listenerPayload, err := json.Marshal(map[string]interface{}{
+		"port":     dpPortInt,
+		"ip":       "127.0.0.1",
+		"protocol": "HTTP",
+		"apis":     []string{"items"},
+	})
+	Expect(err).NotTo(HaveOccurred())
+	resp, err = client.Post(env.CPBaseURL+"/_meta/listeners", "application/json", bytes.NewBuffer(listenerPayload))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(201))
+	resp.Body.Close()
+
+	env.ServerCmd = exec.Command("cargo", "run", "--bin", "apify", "--", "--data-plane", "--config", env.ConfigFile)
+	env.ServerCmd.Dir = projectRoot
+	env.ServerCmd.Env = append(os.Environ(), "APIFY_DB_URL=sqlite://"+env.DBFile, "APIFY_CONFIG_POLL_INTERVAL=1")
+	env.ServerCmd.Stdout = GinkgoWriter
+	env.ServerCmd.Stderr = GinkgoWriter
+	Expect(env.ServerCmd.Start()).To(Succeed())
+
+	Eventually(func() error {
+		resp, err := client.Get(env.BaseURL + "/healthz")
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("status %d", resp.StatusCode)
+		}
+		return nil
+	}, "60s", "1s").Should(Succeed(), "Server failed to start")
+
+	return env
+}
+
+// identityTokenIssuer signs claims with its own RSA key, letting tests play
+// the role of an Azure/GCP metadata endpoint issuing a workload identity token.
+type identityTokenIssuer struct {
+	key *rsa.PrivateKey
+	kid string
+}
+
+func newIdentityTokenIssuer() *identityTokenIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	return &identityTokenIssuer{key: key, kid: "identity-key-1"}
+}
+
+func (i *identityTokenIssuer) jwksJSON() []byte {
+	n := base64.RawURLEncoding.EncodeToString(i.key.PublicKey.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(i.key.PublicKey.E)).Bytes())
+	body, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]interface{}{
+			{"kty": "RSA", "use": "sig", "alg": "RS256", "kid": i.kid, "n": n, "e": e},
+		},
+	})
+	return body
+}
+
+func (i *identityTokenIssuer) issue(claims map[string]interface{}) string {
+	header := map[string]interface{}{"alg": "RS256", "typ": "JWT", "kid": i.kid}
+	headerJSON, _ := json.Marshal(header)
+	claimsJSON, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	hashed := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, hashed[:])
+	Expect(err).NotTo(HaveOccurred())
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+var _ = Describe("Workload Identity Authentication", func() {
+	var (
+		issuer *identityTokenIssuer
+		jwks   *httptest.Server
+		env    *TestEnv
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		issuer = newIdentityTokenIssuer()
+		mux := http.NewServeMux()
+		mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(issuer.jwksJSON())
+		})
+		jwks = httptest.NewServer(mux)
+		client = &http.Client{Timeout: 10 * time.Second}
+	})
+
+	AfterEach(func() {
+		if env != nil {
+			env.Stop()
+		}
+		jwks.Close()
+	})
+
+	identityGet := func(token string) *http.Response {
+		req, err := http.NewRequest("GET", env.BaseURL+"/items", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Identity-Token", token)
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	Context("Azure managed identity", func() {
+		BeforeEach(func() {
+			providerYAML := fmt.Sprintf(`  - name: azure-identity
+    type: workload-identity
+    enabled: true
+    config:
+      provider: azure
+      jwks_url: %s/keys
+      allow:
+        - subscription: "11111111-1111-1111-1111-111111111111"
+          resource_group: "apify-rg"
+`, jwks.URL)
+			env = startWorkloadIdentityEnv(providerYAML)
+		})
+
+		It("should accept a VM-identity token whose xms_mirid matches the allowlist", func() {
+			token := issuer.issue(map[string]interface{}{
+				"aud":       "https://management.azure.com/",
+				"exp":       time.Now().Add(time.Hour).Unix(),
+				"xms_mirid": "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/apify-rg/providers/Microsoft.Compute/virtualMachines/worker-1",
+			})
+			resp := identityGet(token)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("should accept a user-assigned managed identity token in the same resource group", func() {
+			token := issuer.issue(map[string]interface{}{
+				"aud":       "https://management.azure.com/",
+				"exp":       time.Now().Add(time.Hour).Unix(),
+				"xms_mirid": "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/apify-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/worker-identity",
+			})
+			resp := identityGet(token)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("should reject a token from a subscription outside the allowlist", func() {
+			token := issuer.issue(map[string]interface{}{
+				"aud":       "https://management.azure.com/",
+				"exp":       time.Now().Add(time.Hour).Unix(),
+				"xms_mirid": "/subscriptions/99999999-9999-9999-9999-999999999999/resourceGroups/apify-rg/providers/Microsoft.Compute/virtualMachines/worker-1",
+			})
+			resp := identityGet(token)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Context("GCP ID token", func() {
+		BeforeEach(func() {
+			providerYAML := fmt.Sprintf(`  - name: gcp-identity
+    type: workload-identity
+    enabled: true
+    config:
+      provider: gcp
+      jwks_url: %s/keys
+      audience: "https://apify.internal/"
+      allow:
+        - email: "worker@apify-project.iam.gserviceaccount.com"
+`, jwks.URL)
+			env = startWorkloadIdentityEnv(providerYAML)
+		})
+
+		It("should accept a Google-issued ID token with a matching email and audience", func() {
+			token := issuer.issue(map[string]interface{}{
+				"aud":   "https://apify.internal/",
+				"exp":   time.Now().Add(time.Hour).Unix(),
+				"email": "worker@apify-project.iam.gserviceaccount.com",
+			})
+			resp := identityGet(token)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("should reject a token with the wrong audience", func() {
+			token := issuer.issue(map[string]interface{}{
+				"aud":   "https://some-other-service/",
+				"exp":   time.Now().Add(time.Hour).Unix(),
+				"email": "worker@apify-project.iam.gserviceaccount.com",
+			})
+			resp := identityGet(token)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should reject a token whose email isn't in the allowlist", func() {
+			token := issuer.issue(map[string]interface{}{
+				"aud":   "https://apify.internal/",
+				"exp":   time.Now().Add(time.Hour).Unix(),
+				"email": "someone-else@apify-project.iam.gserviceaccount.com",
+			})
+			resp := identityGet(token)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Context("when no identity token header is present", func() {
+		BeforeEach(func() {
+			env = startWorkloadIdentityEnv("")
+		})
+
+		It("should fall through to the X-Api-Key middleware", func() {
+			req, err := http.NewRequest("GET", env.BaseURL+"/items", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("X-Api-Key", env.APIKey)
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+	})
+})